@@ -19,7 +19,7 @@ var addCmd = &cobra.Command{
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if !isValidProject() {
-			fmt.Println("错误: 不在有效的 dsserv 项目目录中 (未找到 manifest.json)")
+			printError(T("common.error_not_project"))
 			return
 		}
 
@@ -29,25 +29,41 @@ var addCmd = &cobra.Command{
 		}
 
 		if name == "" {
-			fmt.Println("错误: 需要可执行文件名称")
+			printError(T("add.name_required"))
+			return
+		}
+
+		if err := validateName(T("name.kind.executable"), name); err != nil {
+			printError(T("common.error", err))
+			return
+		}
+
+		if err := checkNewExecutable(name); err != nil {
+			printError(T("common.error", err))
 			return
 		}
 
 		if err := createExecutableInCmd(name); err != nil {
-			fmt.Printf("添加可执行文件时出错: %v\n", err)
+			printError(T("add.failed", err))
 			return
 		}
 
 		// 更新manifest.json的executable字段
 		if err := updateManifestExecutable(name); err != nil {
-			fmt.Printf("更新manifest.json时出错: %v\n", err)
+			printError(T("add.update_manifest_failed", err))
 			return
 		}
 
-		fmt.Printf("\n✅ 可执行文件 '%s' 添加成功!\n", name)
-		fmt.Printf("\n下一步操作:\n")
-		fmt.Printf("  1. 编辑 cmd/%s/main.go 实现您的逻辑\n", name)
-		fmt.Printf("  2. 运行 'dscli build' 自动构建所有可执行文件\n")
+		emitEvent(Event{Type: EventExecutableAdded, Executable: name, Source: "./" + filepath.ToSlash(filepath.Join("cmd", name))})
+		logInfo("\n" + T("add.done", name))
+		logInfo("\n" + T("common.next_steps"))
+		logInfo(T("add.next_edit", name))
+		if len(buildConfig.Executables) > 0 {
+			logInfo(T("add.next_config", name, name))
+			logInfo(T("add.next_build_explicit"))
+		} else {
+			logInfo(T("add.next_build"))
+		}
 	},
 }
 
@@ -56,22 +72,51 @@ func init() {
 
 }
 
+// checkNewExecutable 检查新的可执行文件是否会与现有的可执行文件重名
+func checkNewExecutable(name string) error {
+	if err := loadBuildConfig(); err != nil {
+		return err
+	}
+
+	manifest, err := readManifest()
+	if err != nil {
+		return fmt.Errorf(T("common.read_manifest_failed"), err)
+	}
+	projectName, _ := manifest["name"].(string)
+
+	executables, err := discoverExecutables(projectName)
+	if err != nil {
+		return err
+	}
+
+	source := "./" + filepath.ToSlash(filepath.Join("cmd", name))
+	for _, exe := range executables {
+		if exe.Source == source {
+			return nil // 已存在的可执行文件由 createExecutableInCmd 处理
+		}
+	}
+	return checkExecutableCollisions(append(executables, Executable{Name: name, Source: source}))
+}
+
 func createExecutableInCmd(name string) error {
 	// 创建cmd目录（如果不存在）
 	if err := os.MkdirAll("cmd", 0755); err != nil {
-		return fmt.Errorf("创建cmd目录失败: %w", err)
+		return fmt.Errorf(T("add.create_cmd_dir_failed"), err)
 	}
 
-	// 创建可执行文件的源码目录
+	// 创建可执行文件的源码目录，并确保不会写到 cmd 目录之外
 	execDir := filepath.Join("cmd", name)
+	if err := ensureWithinDir("cmd", execDir); err != nil {
+		return err
+	}
 	if err := os.MkdirAll(execDir, 0755); err != nil {
-		return fmt.Errorf("创建目录 %s 失败: %w", execDir, err)
+		return fmt.Errorf(T("common.create_dir_failed"), execDir, err)
 	}
 
 	// 检查是否已存在 main.go
 	mainGoPath := filepath.Join(execDir, "main.go")
 	if _, err := os.Stat(mainGoPath); err == nil {
-		fmt.Printf("源码文件已存在: %s\n", mainGoPath)
+		logInfo(T("add.source_exists", mainGoPath))
 		return nil
 	}
 
@@ -84,17 +129,18 @@ import (
 )
 
 func main() {
-	fmt.Printf("Starting %s...\n")
-	// TODO: 在这里实现您的 %s 逻辑
-	log.Println("%s is running")
+	fmt.Printf("%s\n")
+	// TODO: %s
+	log.Println("%s")
 }
-`, name, name, name)
+`, T("scaffold.exe.starting", name), T("scaffold.exe.todo", name), T("scaffold.exe.running", name))
 
 	if err := os.WriteFile(mainGoPath, []byte(mainGoTemplate), 0644); err != nil {
-		return fmt.Errorf("创建文件 %s 失败: %w", mainGoPath, err)
+		return fmt.Errorf(T("common.create_file_failed"), mainGoPath, err)
 	}
+	emitEvent(Event{Type: EventFileWritten, Path: mainGoPath, Size: int64(len(mainGoTemplate))})
 
-	fmt.Printf("创建了模板文件: %s\n", mainGoPath)
+	logInfo(T("add.template_created", mainGoPath))
 	return nil
 }
 
@@ -103,12 +149,12 @@ func updateManifestExecutable(name string) error {
 	// 读取现有的manifest.json
 	data, err := os.ReadFile("manifest.json")
 	if err != nil {
-		return fmt.Errorf("读取manifest.json失败: %w", err)
+		return fmt.Errorf(T("common.read_manifest_failed"), err)
 	}
 
 	var manifest map[string]interface{}
 	if err := json.Unmarshal(data, &manifest); err != nil {
-		return fmt.Errorf("解析manifest.json失败: %w", err)
+		return fmt.Errorf(T("common.parse_manifest_failed"), err)
 	}
 
 	// 获取现有的executable数组
@@ -127,7 +173,7 @@ func updateManifestExecutable(name string) error {
 	newExecutable := fmt.Sprintf("./bin/%s", executableName)
 	for _, exec := range executables {
 		if execStr, ok := exec.(string); ok && execStr == newExecutable {
-			fmt.Printf("可执行文件 %s 已存在于manifest.json中\n", newExecutable)
+			logInfo(T("add.already_in_manifest", newExecutable))
 			return nil
 		}
 	}
@@ -139,13 +185,13 @@ func updateManifestExecutable(name string) error {
 	// 写回manifest.json
 	updatedData, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
-		return fmt.Errorf("序列化manifest.json失败: %w", err)
+		return fmt.Errorf(T("common.marshal_manifest_failed"), err)
 	}
 
 	if err := os.WriteFile("manifest.json", updatedData, 0644); err != nil {
-		return fmt.Errorf("写入manifest.json失败: %w", err)
+		return fmt.Errorf(T("common.write_manifest_failed"), err)
 	}
 
-	fmt.Printf("已更新manifest.json，添加可执行文件: %s\n", newExecutable)
+	logInfo(T("add.manifest_updated", newExecutable))
 	return nil
 }
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+)
+
+// readPackageFile 从 tar.gz 模块包中读取指定文件的内容
+func readPackageFile(packagePath, name string) ([]byte, error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf(T("archive.not_gzip"), packagePath, err)
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf(T("archive.file_missing"), packagePath, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf(T("archive.read_failed"), packagePath, err)
+		}
+		if path.Clean(header.Name) == name {
+			return io.ReadAll(tarReader)
+		}
+	}
+}
+
+// readPackageManifest 读取模块包中的 manifest.json
+func readPackageManifest(packagePath string) (map[string]interface{}, error) {
+	data, err := readPackageFile(packagePath, "manifest.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf(T("archive.parse_manifest_failed"), packagePath, err)
+	}
+	return manifest, nil
+}
+
+// packageEntry 模块包中的一个条目及其内容
+type packageEntry struct {
+	Header *tar.Header
+	Data   []byte
+}
+
+// readPackageEntries 按顺序读取模块包中的所有条目
+func readPackageEntries(packagePath string) ([]packageEntry, error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf(T("archive.not_gzip"), packagePath, err)
+	}
+	defer gzReader.Close()
+
+	var entries []packageEntry
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf(T("archive.read_failed"), packagePath, err)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf(T("archive.read_entry_failed"), packagePath, header.Name, err)
+		}
+		entries = append(entries, packageEntry{Header: header, Data: data})
+	}
+}
+
+// writePackageEntries 将条目按顺序写入 tar.gz 包
+func writePackageEntries(packagePath string, entries []packageEntry) error {
+	file, err := os.Create(packagePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzWriter)
+	for _, entry := range entries {
+		if err := tarWriter.WriteHeader(entry.Header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(entry.Data); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzWriter.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
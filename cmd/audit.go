@@ -0,0 +1,528 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AuditConfig 漏洞审计配置
+type AuditConfig struct {
+	DB     string `json:"db"`      // 本地 OSV 漏洞数据库目录（如 osv.dev 导出的 Go 生态 JSON 文件）
+	FailOn string `json:"fail_on"` // 达到该严重级别时审计失败: any、low、moderate、high、critical
+}
+
+// OSVEntry OSV 格式的漏洞记录，只解析审计需要的字段
+type OSVEntry struct {
+	ID               string        `json:"id"`
+	Summary          string        `json:"summary"`
+	Aliases          []string      `json:"aliases"`
+	Withdrawn        string        `json:"withdrawn"`
+	Affected         []OSVAffected `json:"affected"`
+	Severity         []OSVSeverity `json:"severity"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// OSVAffected 受影响的软件包及版本范围
+type OSVAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []OSVRange `json:"ranges"`
+	Versions []string   `json:"versions"`
+}
+
+// OSVRange 版本范围，由 introduced、fixed、last_affected 事件组成
+type OSVRange struct {
+	Type   string `json:"type"`
+	Events []struct {
+		Introduced   string `json:"introduced,omitempty"`
+		Fixed        string `json:"fixed,omitempty"`
+		LastAffected string `json:"last_affected,omitempty"`
+	} `json:"events"`
+}
+
+// AuditFinding 一个依赖模块命中的漏洞
+type AuditFinding struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Severity string   `json:"severity"`
+	Module   string   `json:"module"`
+	Version  string   `json:"version"`
+	Fixed    string   `json:"fixed,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+}
+
+// AuditReport 一个审计对象（模块包、SBOM 或可执行文件）的审计结果
+type AuditReport struct {
+	Target   string         `json:"target"`
+	Modules  int            `json:"modules"`
+	Findings []AuditFinding `json:"findings"`
+}
+
+// auditSeverityLevels 严重级别的排序，无法确定严重级别的漏洞记为 unknown。
+// unknown 的漏洞在任何失败阈值下都会导致审计失败。
+var auditSeverityLevels = map[string]int{
+	"unknown":  0,
+	"low":      1,
+	"moderate": 2,
+	"high":     3,
+	"critical": 4,
+}
+
+var (
+	auditDB     string
+	auditFailOn string
+)
+
+// auditCmd 代表 audit 命令
+var auditCmd = &cobra.Command{
+	Use:   "audit [package|sbom|executable]...",
+	Short: "使用本地漏洞数据库审计模块的 Go 依赖",
+	Long: `读取模块包中的 SBOM（没有 SBOM 时读取可执行文件内嵌的 Go 构建信息），
+将 Go 标准库和依赖模块的版本与本地 OSV 格式漏洞数据库比对，报告受影响的模块和修复版本。
+
+审计完全离线进行，数据库目录通过 --db 或 .dscli.json 的 audit.db 指定，
+目录中每个 .json 文件是一条 OSV 漏洞记录（可以按子目录组织）。
+未指定参数时审计输出目录中的所有模块包。
+存在达到 --fail-on 严重级别的漏洞时以非零状态码退出。
+严重级别取自记录的 database_specific.severity 或 CVSS 向量，
+无法确定严重级别的漏洞（Go 漏洞数据库的记录通常如此）在任何阈值下都视为失败。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+
+		targets := args
+		if len(targets) == 0 {
+			packages, err := filepath.Glob(filepath.Join(outputDir(), "*.tar.gz"))
+			if err != nil || len(packages) == 0 {
+				printError(T("audit.no_packages", outputDir()))
+				return
+			}
+			targets = packages
+		}
+
+		reports, err := auditTargets(targets)
+		if err != nil {
+			printError(T("audit.failed", err))
+			return
+		}
+
+		printAuditReports(reports)
+		if failed, threshold := auditFailed(reports); failed {
+			printError(T("audit.threshold_reached", threshold))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVar(&auditDB, "db", "", "本地 OSV 漏洞数据库目录 (覆盖 .dscli.json 中的 audit.db)")
+	auditCmd.Flags().StringVar(&auditFailOn, "fail-on", "", "达到该严重级别时审计失败: any、low、moderate、high、critical")
+}
+
+// outputDir 返回配置的输出目录，默认为 dist
+func outputDir() string {
+	if buildConfig == nil || buildConfig.OutputDir == "" {
+		return "dist"
+	}
+	return buildConfig.OutputDir
+}
+
+// auditDBPath 按 --db、.dscli.json 的顺序确定漏洞数据库目录
+func auditDBPath() string {
+	if auditDB != "" {
+		return auditDB
+	}
+	if buildConfig != nil {
+		return buildConfig.Audit.DB
+	}
+	return ""
+}
+
+// auditThreshold 按 --fail-on、.dscli.json 的顺序确定失败阈值，未配置时返回空字符串
+func auditThreshold() string {
+	if auditFailOn != "" {
+		return auditFailOn
+	}
+	if buildConfig != nil {
+		return buildConfig.Audit.FailOn
+	}
+	return ""
+}
+
+// auditTargets 加载漏洞数据库并审计每个目标
+func auditTargets(targets []string) ([]AuditReport, error) {
+	if threshold := auditThreshold(); threshold != "" && threshold != "any" {
+		if normalizeSeverity(threshold) == "unknown" {
+			return nil, fmt.Errorf(T("audit.invalid_severity"), threshold)
+		}
+	}
+
+	dbPath := auditDBPath()
+	if dbPath == "" {
+		return nil, errors.New(T("audit.no_db"))
+	}
+	db, err := loadOSVDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []AuditReport
+	for _, target := range targets {
+		modules, err := auditModules(target)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, AuditReport{
+			Target:   target,
+			Modules:  len(modules),
+			Findings: matchVulnerabilities(db, modules),
+		})
+	}
+	return reports, nil
+}
+
+// auditFailed 判断是否有漏洞达到失败阈值，同时返回阈值。无法确定严重级别的漏洞总是视为达到阈值。
+func auditFailed(reports []AuditReport) (bool, string) {
+	threshold := auditThreshold()
+	if threshold == "" {
+		return false, ""
+	}
+	minLevel := 0
+	if threshold != "any" {
+		minLevel = auditSeverityLevels[normalizeSeverity(threshold)]
+	}
+	for _, report := range reports {
+		for _, finding := range report.Findings {
+			if finding.Severity == "unknown" || auditSeverityLevels[finding.Severity] >= minLevel {
+				return true, threshold
+			}
+		}
+	}
+	return false, threshold
+}
+
+// loadOSVDatabase 递归读取目录中的 OSV 记录，按 Go 模块路径建立索引，忽略已撤回的记录
+func loadOSVDatabase(dir string) (map[string][]OSVEntry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf(T("audit.db_unavailable"), dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf(T("audit.db_not_dir"), dir)
+	}
+
+	db := make(map[string][]OSVEntry)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		var entry OSVEntry
+		if err := json.Unmarshal(data, &entry); err != nil || entry.ID == "" {
+			// 跳过索引文件等非漏洞记录
+			return nil
+		}
+		if entry.Withdrawn != "" {
+			return nil
+		}
+
+		seen := make(map[string]bool)
+		for _, affected := range entry.Affected {
+			name := affected.Package.Name
+			if !strings.EqualFold(affected.Package.Ecosystem, "Go") || seen[name] {
+				continue
+			}
+			seen[name] = true
+			db[name] = append(db[name], entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(T("audit.read_db_failed"), err)
+	}
+	return db, nil
+}
+
+// auditModules 返回审计对象包含的 Go 模块及其版本，Go 标准库以 "stdlib" 表示。
+// 模块包优先读取包内的 SBOM，没有 SBOM 时读取 bin/ 下可执行文件的构建信息。
+func auditModules(target string) (map[string]string, error) {
+	switch {
+	case strings.HasSuffix(target, ".tar.gz"):
+		if data, err := readPackageFile(target, sbomFileName); err == nil {
+			return sbomModules(data, target)
+		}
+		entries, err := readPackageEntries(target)
+		if err != nil {
+			return nil, err
+		}
+		modules := make(map[string]string)
+		for _, entry := range entries {
+			if path.Dir(path.Clean(entry.Header.Name)) != "bin" || entry.Data == nil {
+				continue
+			}
+			if err := addBuildInfoModules(modules, entry.Data); err != nil {
+				return nil, fmt.Errorf(T("audit.entry_error"), target, entry.Header.Name, err)
+			}
+		}
+		if len(modules) == 0 {
+			return nil, fmt.Errorf(T("audit.no_buildinfo"), target)
+		}
+		return modules, nil
+	case strings.HasSuffix(target, ".json"):
+		data, err := os.ReadFile(target)
+		if err != nil {
+			return nil, err
+		}
+		return sbomModules(data, target)
+	default:
+		data, err := os.ReadFile(target)
+		if err != nil {
+			return nil, err
+		}
+		modules := make(map[string]string)
+		if err := addBuildInfoModules(modules, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", target, err)
+		}
+		return modules, nil
+	}
+}
+
+// sbomModules 从 CycloneDX SBOM 中提取 Go 模块组件
+func sbomModules(data []byte, source string) (map[string]string, error) {
+	var bom CycloneDXBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, fmt.Errorf(T("audit.parse_sbom_failed"), source, err)
+	}
+	modules := make(map[string]string)
+	for _, component := range bom.Components {
+		if strings.HasPrefix(component.PURL, "pkg:golang/") && component.Version != "" {
+			modules[component.Name] = component.Version
+		}
+	}
+	return modules, nil
+}
+
+// addBuildInfoModules 读取可执行文件的构建信息，将标准库和依赖模块加入 modules
+func addBuildInfoModules(modules map[string]string, data []byte) error {
+	info, err := readBuildInfo(data)
+	if err != nil {
+		return err
+	}
+	modules["stdlib"] = info.GoVersion
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			if dep.Replace.Version != "" {
+				modules[dep.Replace.Path] = dep.Replace.Version
+			}
+			continue
+		}
+		modules[dep.Path] = dep.Version
+	}
+	return nil
+}
+
+// matchVulnerabilities 查找影响给定模块版本的漏洞，结果按严重级别从高到低排序
+func matchVulnerabilities(db map[string][]OSVEntry, modules map[string]string) []AuditFinding {
+	findings := []AuditFinding{}
+	for module, version := range modules {
+		v, err := moduleSemVer(module, version)
+		if err != nil {
+			continue // 无法比较的版本（如 (devel)）
+		}
+
+		for _, entry := range db[module] {
+			for _, affected := range entry.Affected {
+				if affected.Package.Name != module {
+					continue
+				}
+				hit, fixed := osvAffects(affected, v)
+				if !hit {
+					continue
+				}
+				if fixed != "" {
+					if module == "stdlib" {
+						fixed = "go" + fixed
+					} else {
+						fixed = "v" + fixed
+					}
+				}
+				findings = append(findings, AuditFinding{
+					ID:       entry.ID,
+					Aliases:  entry.Aliases,
+					Severity: osvSeverity(entry),
+					Module:   module,
+					Version:  version,
+					Fixed:    fixed,
+					Summary:  entry.Summary,
+				})
+				break
+			}
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if auditSeverityLevels[a.Severity] != auditSeverityLevels[b.Severity] {
+			return auditSeverityLevels[a.Severity] > auditSeverityLevels[b.Severity]
+		}
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		return a.ID < b.ID
+	})
+	return findings
+}
+
+// goVersionPattern Go 工具链版本，如 go1.21、go1.21.5、go1.22rc1
+var goVersionPattern = regexp.MustCompile(`^go(\d+)\.(\d+)(?:\.(\d+))?(?:(beta|rc)(\d+))?`)
+
+// moduleSemVer 将模块版本解析为语义化版本，标准库的 Go 版本转换为 OSV 使用的格式
+func moduleSemVer(module, version string) (*SemVer, error) {
+	if module != "stdlib" {
+		return parseSemVer(version)
+	}
+	m := goVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf(T("audit.invalid_go_version"), version)
+	}
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
+	}
+	s := fmt.Sprintf("%s.%s.%s", m[1], m[2], patch)
+	if m[4] != "" {
+		s += "-" + m[4] + "." + m[5]
+	}
+	return parseSemVer(s)
+}
+
+// osvAffects 判断版本是否在受影响范围内，命中时同时返回对应的修复版本（不带前缀）
+func osvAffects(affected OSVAffected, v *SemVer) (bool, string) {
+	for _, listed := range affected.Versions {
+		if lv, err := parseSemVer(listed); err == nil && lv.Compare(v) == 0 {
+			return true, ""
+		}
+	}
+
+	for _, r := range affected.Ranges {
+		if r.Type != "SEMVER" {
+			continue
+		}
+
+		// 按 OSV 规范依次处理不大于 v 的事件，最后一个生效的事件决定是否受影响
+		type event struct {
+			kind    string
+			version *SemVer
+		}
+		var events []event
+		for _, e := range r.Events {
+			kind, raw := "introduced", e.Introduced
+			switch {
+			case e.Fixed != "":
+				kind, raw = "fixed", e.Fixed
+			case e.LastAffected != "":
+				kind, raw = "last_affected", e.LastAffected
+			}
+			if raw == "0" {
+				raw = "0.0.0"
+			}
+			ev, err := parseSemVer(raw)
+			if err != nil {
+				continue
+			}
+			events = append(events, event{kind: kind, version: ev})
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].version.Compare(events[j].version) < 0
+		})
+
+		hit := false
+		for _, e := range events {
+			cmp := e.version.Compare(v)
+			switch e.kind {
+			case "introduced":
+				if cmp <= 0 {
+					hit = true
+				}
+			case "fixed":
+				if cmp <= 0 {
+					hit = false
+				}
+			case "last_affected":
+				if cmp < 0 {
+					hit = false
+				}
+			}
+		}
+		if !hit {
+			continue
+		}
+
+		for _, e := range events {
+			if e.kind == "fixed" && e.version.Compare(v) > 0 {
+				return true, e.version.String()
+			}
+		}
+		return true, ""
+	}
+	return false, ""
+}
+
+// normalizeSeverity 统一严重级别的写法，如 "HIGH"、"Medium"
+func normalizeSeverity(severity string) string {
+	s := strings.ToLower(strings.TrimSpace(severity))
+	if s == "medium" {
+		s = "moderate"
+	}
+	if _, ok := auditSeverityLevels[s]; !ok {
+		return "unknown"
+	}
+	return s
+}
+
+// printAuditReports 输出审计结果，结构化输出时为每个对象记录一个 audit.report 事件
+func printAuditReports(reports []AuditReport) {
+	total := 0
+	for _, report := range reports {
+		emitEvent(Event{Type: EventAuditReport, Target: report.Target, Data: report})
+		logInfo(T("audit.target", report.Target, report.Modules))
+		if len(report.Findings) == 0 {
+			logInfo(T("audit.clean"))
+			continue
+		}
+		for _, finding := range report.Findings {
+			fixed := T("audit.no_fix")
+			if finding.Fixed != "" {
+				fixed = T("audit.fixed_in", finding.Fixed)
+			}
+			logWarn(T("audit.finding", finding.ID, strings.ToUpper(finding.Severity), finding.Module, finding.Version, fixed))
+			if finding.Summary != "" {
+				logWarn(fmt.Sprintf("     %s", finding.Summary))
+			}
+		}
+		total += len(report.Findings)
+	}
+	logInfo(T("audit.done", len(reports), total))
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestCVSSV3Score(t *testing.T) {
+	tests := []struct {
+		vector string
+		score  float64
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H", 7.5},
+		{"CVSS:3.0/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N", 3.1},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N", 6.4},
+		{"CVSS:3.1/AV:L/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0},
+	}
+	for _, tt := range tests {
+		score, err := cvssV3Score(tt.vector)
+		if err != nil {
+			t.Errorf("cvssV3Score(%q) error: %v", tt.vector, err)
+			continue
+		}
+		if score != tt.score {
+			t.Errorf("cvssV3Score(%q) = %v, want %v", tt.vector, score, tt.score)
+		}
+	}
+
+	for _, vector := range []string{"AV:N/AC:L", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/C:H/I:H/A:H", "CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"} {
+		if _, err := cvssV3Score(vector); err == nil {
+			t.Errorf("cvssV3Score(%q) should fail", vector)
+		}
+	}
+}
+
+func TestCVSSV2Score(t *testing.T) {
+	tests := []struct {
+		vector string
+		score  float64
+	}{
+		{"AV:N/AC:L/Au:N/C:C/I:C/A:C", 10.0},
+		{"AV:N/AC:L/Au:N/C:P/I:P/A:P", 7.5},
+		{"AV:N/AC:M/Au:N/C:N/I:P/A:N", 4.3},
+	}
+	for _, tt := range tests {
+		score, err := cvssV2Score(tt.vector)
+		if err != nil {
+			t.Errorf("cvssV2Score(%q) error: %v", tt.vector, err)
+			continue
+		}
+		if score != tt.score {
+			t.Errorf("cvssV2Score(%q) = %v, want %v", tt.vector, score, tt.score)
+		}
+	}
+}
+
+func TestOSVSeverity(t *testing.T) {
+	var entry OSVEntry
+	if got := osvSeverity(entry); got != "unknown" {
+		t.Errorf("entry without severity = %q, want unknown", got)
+	}
+
+	entry.Severity = []OSVSeverity{
+		{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:H/PR:N/UI:R/S:U/C:L/I:N/A:N"},
+		{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H"},
+		{Type: "CVSS_V4", Score: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"},
+	}
+	if got := osvSeverity(entry); got != "high" {
+		t.Errorf("entry with CVSS vectors = %q, want high", got)
+	}
+
+	entry.DatabaseSpecific.Severity = "MEDIUM"
+	if got := osvSeverity(entry); got != "moderate" {
+		t.Errorf("entry with database_specific.severity = %q, want moderate", got)
+	}
+}
+
+func TestAuditFailedUnknownSeverity(t *testing.T) {
+	defer func(failOn string, config *BuildConfig) { auditFailOn, buildConfig = failOn, config }(auditFailOn, buildConfig)
+	buildConfig = nil
+
+	reports := []AuditReport{{Target: "app.tar.gz", Findings: []AuditFinding{{ID: "GO-2024-0001", Severity: "unknown"}}}}
+	for _, threshold := range []string{"any", "low", "moderate", "high", "critical"} {
+		auditFailOn = threshold
+		if failed, _ := auditFailed(reports); !failed {
+			t.Errorf("unknown severity should fail --fail-on %s", threshold)
+		}
+	}
+
+	reports[0].Findings[0].Severity = "moderate"
+	auditFailOn = "high"
+	if failed, _ := auditFailed(reports); failed {
+		t.Error("moderate finding should not fail --fail-on high")
+	}
+	auditFailOn = ""
+	if failed, _ := auditFailed(reports); failed {
+		t.Error("audit without threshold should not fail")
+	}
+}
+
+func TestPrintAuditReportsEmitsEvents(t *testing.T) {
+	defer func(format string, writer io.Writer) { outputFormat, eventWriter = format, writer }(outputFormat, eventWriter)
+	var buf bytes.Buffer
+	outputFormat, eventWriter = "ndjson", &buf
+
+	printAuditReports([]AuditReport{{Target: "app.tar.gz", Modules: 3, Findings: []AuditFinding{{ID: "GO-2024-0001", Severity: "high"}}}})
+
+	var event struct {
+		Type   string      `json:"type"`
+		Target string      `json:"target"`
+		Data   AuditReport `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("invalid event %q: %v", buf.String(), err)
+	}
+	if event.Type != EventAuditReport || event.Target != "app.tar.gz" || event.Data.Modules != 3 || len(event.Data.Findings) != 1 {
+		t.Errorf("unexpected audit event: %+v", event)
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
@@ -31,6 +31,17 @@ type BuildConfig struct {
 	Assets    interface{} `json:"assets"`     // 需要打包的资源文件/目录，支持字符串数组或对象数组
 	Excludes  []string    `json:"excludes"`   // 排除的文件/目录
 	OutputDir string      `json:"output_dir"` // 输出目录
+	// 显式指定的可执行文件列表，为空时自动发现根目录和 cmd 目录下的 main 包
+	Executables []Executable             `json:"executables"`
+	Signing     SigningConfig            `json:"signing"`     // 包签名配置
+	Publish     PublishConfig            `json:"publish"`     // 模块仓库发布配置
+	Repo        string                   `json:"repo"`        // 本地模块仓库目录，用于 install name@version
+	ModulesDir  string                   `json:"modules_dir"` // Agent 模块目录，用于 install、uninstall 和 rollback
+	SBOM        *bool                    `json:"sbom"`        // 是否为每个包生成 CycloneDX SBOM，默认为 true
+	Audit       AuditConfig              `json:"audit"`       // 离线漏洞审计配置
+	Hooks       HooksConfig              `json:"hooks"`       // 构建钩子
+	Stages      interface{}              `json:"stages"`      // 构建前阶段: generate、vet、test
+	Profiles    map[string]ProfileConfig `json:"profiles"`    // 配置档案，通过 --profile 选择
 }
 
 var (
@@ -44,8 +55,13 @@ var (
 		{"linux", "amd64"},
 		{"linux", "arm64"},
 	}
-	targetFlag  string
-	buildConfig *BuildConfig
+	targetFlag     string
+	versionFromGit bool
+	signPackages   bool
+	auditOnBuild   bool
+	buildConfig    *BuildConfig
+	// buildVersion 非空时覆盖 manifest.json 中的版本号
+	buildVersion string
 )
 
 // buildCmd 代表 build 命令
@@ -57,27 +73,38 @@ var buildCmd = &cobra.Command{
 更新 manifest.json 文件的构建信息，并创建特定平台的 tar.gz 包。`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := buildProject(); err != nil {
-			fmt.Printf("构建失败: %v\n", err)
+			logError(T("build.failed", err))
+			emitError(err)
+			emitEvent(Event{Type: EventBuildFinished, Success: boolPtr(false)})
+			setExitCode(1)
 			return
 		}
-		fmt.Println("\n✅ 构建完成!")
+		logInfo("\n" + T("build.done"))
+		emitEvent(Event{Type: EventBuildFinished, Success: boolPtr(true)})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(buildCmd)
-	buildCmd.Flags().StringVarP(&targetFlag, "target", "t", "", "指定目标平台和架构 (格式: os/arch，如 linux/amd64) 或 'all' 编译所有平台")
+	buildCmd.Flags().StringVarP(&targetFlag, "target", "t", "", "目标平台，逗号分隔: os/arch、all、通配符 (如 linux/*、*/arm64) 或以 ! 开头的排除项 (如 all,!windows/386)")
+	buildCmd.Flags().BoolVar(&versionFromGit, "from-git", false, "使用 git describe 推导模块版本号")
+	buildCmd.Flags().BoolVar(&signPackages, "sign", false, "使用 ed25519 私钥为生成的包和校验和文件签名")
+	buildCmd.Flags().StringVarP(&signKeyFile, "key", "k", "", "签名私钥文件路径 (配合 --sign 使用)")
+	buildCmd.Flags().BoolVar(&noCache, "no-cache", false, "不使用构建缓存，重新编译所有目标")
+	buildCmd.Flags().StringVar(&buildProfile, "profile", "", "使用 .dscli.json 中 profiles 定义的配置档案")
+	buildCmd.Flags().BoolVar(&auditOnBuild, "audit", false, "构建完成后使用本地漏洞数据库审计生成的包")
+	buildCmd.Flags().StringVar(&auditFailOn, "fail-on", "", "配合 --audit 使用，达到该严重级别时构建失败")
 }
 
 func buildProject() error {
 	// 检查是否在有效的项目目录中
 	if !isValidProject() {
-		return fmt.Errorf("不在有效的 dsserv 项目目录中 (未找到 manifest.json)")
+		return errors.New(T("common.not_project"))
 	}
 
 	// 加载构建配置
 	if err := loadBuildConfig(); err != nil {
-		fmt.Printf("Warning: 无法加载构建配置: %v\n", err)
+		logWarn(T("build.config_warning", err))
 		// 使用默认配置
 		buildConfig = &BuildConfig{
 			Assets:    []interface{}{},
@@ -89,16 +116,51 @@ func buildProject() error {
 	// 读取当前清单
 	manifest, err := readManifest()
 	if err != nil {
-		return fmt.Errorf("读取 manifest.json 失败: %w", err)
+		return fmt.Errorf(T("common.read_manifest_failed"), err)
 	}
 
 	projectName := manifest["name"].(string)
-	fmt.Printf("正在构建项目: %s\n", projectName)
+	logInfo(T("build.project", projectName))
+
+	if versionFromGit {
+		v, err := gitDescribeVersion()
+		if err != nil {
+			return fmt.Errorf(T("build.git_version_failed"), err)
+		}
+		buildVersion = v
+		logInfo(T("build.git_version", buildVersion))
+	}
 
 	// 确定要构建的目标
 	targets, err := getTargetsToBuild()
 	if err != nil {
-		return fmt.Errorf("获取构建目标失败: %w", err)
+		return fmt.Errorf(T("build.targets_failed"), err)
+	}
+
+	// 确定要构建的可执行文件
+	executables, err := discoverExecutables(projectName)
+	if err != nil {
+		return err
+	}
+	if len(executables) == 0 {
+		return errors.New(T("build.no_executables"))
+	}
+
+	startEvent := Event{Type: EventBuildStarted, Project: projectName, Version: buildVersion}
+	if startEvent.Version == "" {
+		startEvent.Version, _ = manifest["version"].(string)
+	}
+	for _, target := range targets {
+		startEvent.Targets = append(startEvent.Targets, target.OS+"/"+target.Arch)
+	}
+	emitEvent(startEvent)
+
+	if err := validateHooks(buildConfig.Hooks); err != nil {
+		return err
+	}
+	stages, err := selectedStages()
+	if err != nil {
+		return err
 	}
 
 	// 确定输出目录
@@ -106,106 +168,249 @@ func buildProject() error {
 	if distDir == "" {
 		distDir = "dist"
 	}
-	if err := os.RemoveAll(distDir); err != nil {
-		return fmt.Errorf("清理输出目录失败: %w", err)
-	}
+	// 不清空输出目录，每个目标只替换自己生成的包，使用 dscli clean 删除旧的构建产物
 	if err := os.MkdirAll(distDir, 0755); err != nil {
-		return fmt.Errorf("创建输出目录失败: %w", err)
+		return fmt.Errorf(T("build.output_dir_failed"), err)
+	}
+
+	if err := runHooks("before_build", buildConfig.Hooks.BeforeBuild, hookEnv("before_build", projectName, distDir)); err != nil {
+		return err
+	}
+
+	// 运行构建前阶段，失败时中止构建
+	stageResults, err := runStages(stages)
+	if err != nil {
+		logInfo("\n" + T("build.stages_header"))
+		printStageResults(stageResults)
+		return err
+	}
+
+	var cache *BuildCache
+	if !noCache {
+		if cache, err = openBuildCache(distDir); err != nil {
+			logWarn(T("build.cache_unavailable", err))
+			cache = nil
+		}
 	}
 
-	// 为每个目标平台构建
+	// 为每个目标平台构建，交互式终端中显示每个目标的进度
+	if progressEnabled() {
+		progress = startProgress(targets)
+	}
+	// 单个目标失败时继续构建其余目标，全部结束后以错误退出
+	failedTargets := 0
 	for _, target := range targets {
-		fmt.Printf("正在为 %s/%s 构建...\n", target.OS, target.Arch)
-		if err := buildForTarget(projectName, target, distDir); err != nil {
-			fmt.Printf("警告: %s/%s 构建失败: %v\n", target.OS, target.Arch, err)
+		logInfo(T("build.target", target.OS, target.Arch))
+		emitEvent(Event{Type: EventTargetStarted, Target: target.OS + "/" + target.Arch})
+		if err := buildForTarget(projectName, target, executables, distDir, cache); err != nil {
+			progress.failed(target, err)
+			logWarn(T("build.target_failed", target.OS, target.Arch, err))
+			emitEvent(Event{Type: EventTargetFailed, Target: target.OS + "/" + target.Arch, Error: err.Error()})
+			failedTargets++
 			continue
 		}
 	}
+	progress.finish()
+	progress = nil
+
+	checksumPath, err := writeChecksums(distDir)
+	if err != nil {
+		return fmt.Errorf(T("build.checksums_failed"), err)
+	}
+	emitEvent(Event{Type: EventChecksumsWritten, Path: checksumPath})
+
+	if signPackages {
+		files, err := signableFiles(distDir)
+		if err != nil {
+			return err
+		}
+		if err := signFiles(files); err != nil {
+			return fmt.Errorf(T("build.sign_failed"), err)
+		}
+	}
+
+	if err := runHooks("after_build", buildConfig.Hooks.AfterBuild, hookEnv("after_build", projectName, distDir)); err != nil {
+		return err
+	}
 
-	fmt.Println("\n构建摘要:")
+	logInfo("\n" + T("build.summary"))
+	printStageResults(stageResults)
 	files, _ := filepath.Glob(filepath.Join(distDir, "*.tar.gz"))
 	for _, file := range files {
 		info, _ := os.Stat(file)
-		fmt.Printf("  %s (%.2f MB)\n", filepath.Base(file), float64(info.Size())/1024/1024)
+		logInfo(fmt.Sprintf("  %s (%.2f MB)", filepath.Base(file), float64(info.Size())/1024/1024))
+		if _, err := os.Stat(file + sbomSuffix); err == nil {
+			logInfo("  " + filepath.Base(file+sbomSuffix))
+		}
 	}
+	logInfo("  " + filepath.Base(checksumPath))
 
+	if auditOnBuild {
+		logInfo("\n" + T("build.auditing"))
+		reports, err := auditTargets(files)
+		if err != nil {
+			return fmt.Errorf(T("build.audit_error"), err)
+		}
+		printAuditReports(reports)
+		if failed, threshold := auditFailed(reports); failed {
+			return fmt.Errorf(T("build.audit_failed"), threshold)
+		}
+	}
+
+	if failedTargets > 0 {
+		return fmt.Errorf(T("build.some_targets_failed"), failedTargets, len(targets))
+	}
 	return nil
 }
 
-func buildForTarget(projectName string, target BuildTarget, distDir string) error {
+func buildForTarget(projectName string, target BuildTarget, executables []Executable, distDir string, cache *BuildCache) error {
 	// 设置交叉编译的环境变量
 	env := os.Environ()
 	env = append(env, fmt.Sprintf("GOOS=%s", target.OS))
 	env = append(env, fmt.Sprintf("GOARCH=%s", target.Arch))
 	env = append(env, "CGO_ENABLED=0")
 
+	packageName := fmt.Sprintf("%s_%s_%s.tar.gz", projectName, target.OS, target.Arch)
+	packagePath := filepath.Join(distDir, packageName)
+
+	// 删除此目标上一次构建的包及其签名和 SBOM，避免留下过期的附属文件
+	for _, path := range []string{packagePath, packagePath + signatureSuffix, packagePath + sbomSuffix} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf(T("build.remove_stale_failed"), err)
+		}
+	}
+
+	// 源码、编译参数、资源文件都未变化时直接复用缓存的模块包，不再运行目标相关的钩子
+	var packageKey string
+	if cache != nil {
+		key, err := cache.packageKey(target, executables)
+		if err != nil {
+			logWarn(T("build.cache_key_failed", err))
+		} else {
+			packageKey = key
+			logTrace(T("log.cache_key", packageName, packageKey))
+			if cache.restorePackage(packageKey, distDir) {
+				if manifest, err := readPackageManifest(packagePath); err == nil {
+					buildDate, _ := manifest["build_date"].(string)
+					if err := updateManifestForTarget(target, buildDate); err != nil {
+						return fmt.Errorf(T("build.update_manifest_failed"), err)
+					}
+				}
+				logInfo(T("build.cached_package", packageName))
+				emitPackageWritten(target, packagePath, true)
+				progress.done(target, packagePath, true)
+				return nil
+			}
+		}
+	}
+
+	if err := runHooks("before_target", buildConfig.Hooks.BeforeTarget, hookEnv("before_target", projectName, distDir, targetHookEnv(target)...)); err != nil {
+		return err
+	}
+
 	buildTime := time.Now().Format(time.RFC3339)
-	var builtBinaries []string
+	var builtBinaries, failedExecutables []string
 
-	// 1. 构建主程序（根目录的main.go）
-	if _, err := os.Stat("main.go"); err == nil {
-		binaryName := projectName
+	for i, exe := range executables {
+		progress.compiling(target, exe.Name, i+1, len(executables))
+		binaryName := exe.Name
 		if target.OS == "windows" {
 			binaryName += ".exe"
 		}
 
+		binaryPath := filepath.Join("bin", binaryName)
+		var binaryKey string
+		if cache != nil {
+			binaryKey = cache.binaryKey(target, exe)
+			logTrace(T("log.cache_key", exe.Name, binaryKey))
+			if cache.restoreBinary(binaryKey, binaryPath) {
+				builtBinaries = append(builtBinaries, binaryName)
+				logInfo(T("build.cached_executable", exe.Name, exe.Source))
+				emitEvent(Event{Type: EventExecutableBuilt, Target: target.OS + "/" + target.Arch, Executable: exe.Name, Source: exe.Source, Path: binaryPath, Cached: true})
+				continue
+			}
+		}
+
 		ldflags := fmt.Sprintf("-ldflags=-X main.buildDate=%s", buildTime)
-		cmd := exec.Command("go", "build", ldflags, "-o", filepath.Join("bin", binaryName), ".")
+		if buildVersion != "" {
+			ldflags += fmt.Sprintf(" -X main.version=%s", buildVersion)
+		}
+		cmd := exec.Command("go", "build", ldflags, "-o", binaryPath, exe.Source)
 		cmd.Env = env
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		cmd.Stdout, cmd.Stderr = commandOutput()
+		logDebug(T("log.exec", fmt.Sprintf("GOOS=%s GOARCH=%s CGO_ENABLED=0 %s", target.OS, target.Arch, strings.Join(cmd.Args, " "))))
 
 		if err := cmd.Run(); err != nil {
-			fmt.Printf("❌ 构建主程序失败: %v\n", err)
-		} else {
-			builtBinaries = append(builtBinaries, binaryName)
-			fmt.Printf("✅ 构建完成: %s (主程序)\n", projectName)
+			logError(T("build.executable_failed", exe.Name, err))
+			emitEvent(Event{Type: EventExecutableFailed, Target: target.OS + "/" + target.Arch, Executable: exe.Name, Source: exe.Source, Error: err.Error()})
+			failedExecutables = append(failedExecutables, exe.Name)
+			continue
 		}
-	}
 
-	// 2. 自动发现并构建cmd目录下的子目录
-	cmdExecutables, err := discoverCmdExecutables()
-	if err != nil {
-		fmt.Printf("⚠️  扫描cmd目录失败: %v\n", err)
-	} else {
-		for _, execName := range cmdExecutables {
-			binaryName := execName
-			if target.OS == "windows" {
-				binaryName += ".exe"
-			}
+		builtBinaries = append(builtBinaries, binaryName)
+		logInfo(T("build.executable_built", exe.Name, exe.Source))
+		emitEvent(Event{Type: EventExecutableBuilt, Target: target.OS + "/" + target.Arch, Executable: exe.Name, Source: exe.Source, Path: binaryPath})
 
-			sourcePath := "./" + filepath.Join("cmd", execName)
-			ldflags := fmt.Sprintf("-ldflags=-X main.buildDate=%s", buildTime)
-			cmd := exec.Command("go", "build", ldflags, "-o", filepath.Join("bin", binaryName), sourcePath)
-			cmd.Env = env
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-
-			if err := cmd.Run(); err != nil {
-				fmt.Printf("❌ 构建 %s 失败: %v\n", execName, err)
-				continue
+		if cache != nil {
+			if err := cache.storeBinary(binaryKey, binaryPath); err != nil {
+				logWarn(T("build.cache_store_failed", exe.Name, err))
 			}
-
-			builtBinaries = append(builtBinaries, binaryName)
-			fmt.Printf("✅ 构建完成: %s\n", execName)
 		}
 	}
 
+	// 缺少可执行文件的包不完整，不打包
+	if len(failedExecutables) > 0 {
+		return fmt.Errorf(T("build.executables_failed"), strings.Join(failedExecutables, ", "))
+	}
 	if len(builtBinaries) == 0 {
-		return fmt.Errorf("没有成功构建任何可执行文件")
+		return errors.New(T("build.nothing_built"))
 	}
 
 	// 为此目标更新清单
 	if err := updateManifestForTarget(target, buildTime); err != nil {
-		return fmt.Errorf("更新清单失败: %w", err)
+		return fmt.Errorf(T("build.update_manifest_failed"), err)
 	}
 
 	// 创建包
-	packageName := fmt.Sprintf("%s_%s_%s.tar.gz", projectName, target.OS, target.Arch)
-	packagePath := filepath.Join(distDir, packageName)
+	progress.packaging(target)
+	absPackagePath, _ := filepath.Abs(packagePath)
+	targetEnv := append(targetHookEnv(target), binaryPathsEnv(builtBinaries), "DSCLI_PACKAGE_PATH="+absPackagePath)
 
-	if err := createPackage(packagePath, builtBinaries); err != nil {
-		return fmt.Errorf("failed to create package: %w", err)
+	if err := runHooks("after_target", buildConfig.Hooks.AfterTarget, hookEnv("after_target", projectName, distDir, targetEnv...)); err != nil {
+		return err
+	}
+
+	// 生成 SBOM，同时放入包内和输出目录
+	var extras []AssetConfig
+	if sbomEnabled() {
+		sbomPath := packagePath + sbomSuffix
+		if err := writeSBOM(sbomPath, builtBinaries); err != nil {
+			logWarn(T("build.sbom_failed", err))
+		} else {
+			extras = append(extras, AssetConfig{Source: sbomPath, Output: sbomFileName})
+			logInfo(T("build.sbom_written", filepath.Base(sbomPath)))
+		}
+	}
+
+	if err := createPackage(packagePath, builtBinaries, extras); err != nil {
+		return fmt.Errorf(T("build.create_package_failed"), err)
+	}
+
+	if err := runHooks("after_package", buildConfig.Hooks.AfterPackage, hookEnv("after_package", projectName, distDir, targetEnv...)); err != nil {
+		return err
+	}
+	emitPackageWritten(target, packagePath, false)
+	progress.done(target, packagePath, false)
+
+	// 只缓存所有可执行文件都构建成功的包
+	if packageKey != "" && len(builtBinaries) == len(executables) {
+		files := []string{packagePath}
+		for _, extra := range extras {
+			files = append(files, extra.Source)
+		}
+		if err := cache.storePackage(packageKey, files); err != nil {
+			logWarn(T("build.cache_store_failed", packageName, err))
+		}
 	}
 
 	// 清理二进制文件
@@ -216,6 +421,19 @@ func buildForTarget(projectName string, target BuildTarget, distDir string) erro
 	return nil
 }
 
+// emitPackageWritten 输出包已生成的事件，包括大小和 SHA-256
+func emitPackageWritten(target BuildTarget, packagePath string, cached bool) {
+	if !structuredOutput() {
+		return
+	}
+	event := Event{Type: EventPackageWritten, Target: target.OS + "/" + target.Arch, Path: packagePath, Cached: cached}
+	if info, err := os.Stat(packagePath); err == nil {
+		event.Size = info.Size()
+	}
+	event.SHA256, _ = fileSHA256(packagePath)
+	emitEvent(event)
+}
+
 func updateManifestForTarget(target BuildTarget, buildTime string) error {
 	manifest, err := readManifest()
 	if err != nil {
@@ -226,14 +444,12 @@ func updateManifestForTarget(target BuildTarget, buildTime string) error {
 	manifest["build_date"] = buildTime
 	manifest["os"] = target.OS
 	manifest["arch"] = target.Arch
-
-	// 写入更新的清单
-	data, err := json.MarshalIndent(manifest, "", "  ")
-	if err != nil {
-		return err
+	if buildVersion != "" {
+		manifest["version"] = buildVersion
 	}
 
-	return os.WriteFile("manifest.json", data, 0644)
+	// 写入更新的清单
+	return writeManifest(manifest)
 }
 
 // parseAssets 解析assets配置，支持字符串数组和对象数组两种格式
@@ -262,19 +478,39 @@ func parseAssets(assets interface{}) ([]AssetConfig, error) {
 						Output: output,
 					})
 				} else {
-					return nil, fmt.Errorf("asset对象必须包含source和output字段")
+					return nil, errors.New(T("build.asset_object_invalid"))
 				}
 			} else {
-				return nil, fmt.Errorf("不支持的asset格式")
+				return nil, errors.New(T("build.asset_unsupported"))
 			}
 		}
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("assets必须是数组格式")
+	return nil, errors.New(T("build.assets_not_array"))
+}
+
+// writeSBOM 根据刚构建的二进制文件和当前 manifest 生成 SBOM 文件
+func writeSBOM(sbomPath string, builtBinaries []string) error {
+	manifest, err := readManifest()
+	if err != nil {
+		return err
+	}
+
+	var binaryPaths []string
+	for _, binaryName := range builtBinaries {
+		binaryPaths = append(binaryPaths, filepath.Join("bin", binaryName))
+	}
+
+	data, err := generateSBOM(manifest, binaryPaths)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(sbomPath, data, 0644)
 }
 
-func createPackage(packagePath string, builtBinaries []string) error {
+// createPackage 创建模块包，extras 为额外打包的生成文件（如 SBOM），不受 excludes 影响
+func createPackage(packagePath string, builtBinaries []string, extras []AssetConfig) error {
 	file, err := os.Create(packagePath)
 	if err != nil {
 		return err
@@ -295,7 +531,7 @@ func createPackage(packagePath string, builtBinaries []string) error {
 			Typeflag: tar.TypeDir,
 		}
 		if err := tarWriter.WriteHeader(binDirHeader); err != nil {
-			fmt.Printf("⚠️  无法创建bin目录: %v\n", err)
+			logWarn(T("build.add_bin_dir_failed", err))
 		}
 	}
 
@@ -303,7 +539,7 @@ func createPackage(packagePath string, builtBinaries []string) error {
 	for _, binaryName := range builtBinaries {
 		binPath := filepath.Join("bin", binaryName)
 		if err := addFileToTar(tarWriter, binPath, binPath); err != nil {
-			fmt.Printf("⚠️  无法添加二进制文件 %s: %v\n", binaryName, err)
+			logWarn(T("build.add_binary_failed", binaryName, err))
 			continue
 		}
 	}
@@ -313,31 +549,39 @@ func createPackage(packagePath string, builtBinaries []string) error {
 		return err
 	}
 
+	for _, extra := range extras {
+		if err := addFileToTar(tarWriter, extra.Source, extra.Output); err != nil {
+			return err
+		}
+	}
+
 	// 添加配置文件中指定的资源
 	assets, err := parseAssets(buildConfig.Assets)
 	if err != nil {
-		fmt.Printf("⚠️  解析assets配置失败: %v\n", err)
+		logWarn(T("build.parse_assets_failed", err))
 	} else {
 		for _, asset := range assets {
 			// 检查是否被排除
 			if isExcluded(asset.Source) {
-				fmt.Printf("ℹ️  跳过被排除的资源: %s\n", asset.Source)
+				logInfo(T("build.asset_excluded", asset.Source))
+				emitEvent(Event{Type: EventAssetSkipped, Path: asset.Source, Message: "excluded"})
 				continue
 			}
 
 			info, err := os.Stat(asset.Source)
 			if err != nil {
-				fmt.Printf("⚠️  资源文件不存在: %s\n", asset.Source)
+				logWarn(T("build.asset_missing", asset.Source))
+				emitEvent(Event{Type: EventAssetSkipped, Path: asset.Source, Message: "not_found"})
 				continue
 			}
 
 			if info.IsDir() {
 				if err := addDirToTar(tarWriter, asset.Source, asset.Output); err != nil {
-					fmt.Printf("⚠️  无法添加目录 %s: %v\n", asset.Source, err)
+					logWarn(T("build.add_dir_failed", asset.Source, err))
 				}
 			} else {
 				if err := addFileToTar(tarWriter, asset.Source, asset.Output); err != nil {
-					fmt.Printf("⚠️  无法添加文件 %s: %v\n", asset.Source, err)
+					logWarn(T("build.add_file_failed", asset.Source, err))
 				}
 			}
 		}
@@ -389,7 +633,7 @@ func addDirToTar(tarWriter *tar.Writer, dirPath, tarDirPath string) error {
 
 		// 检查文件是否被排除（检查完整路径和文件名）
 		if isExcluded(path) {
-			fmt.Printf("ℹ️  跳过被排除的文件: %s\n", path)
+			logInfo(T("build.file_excluded", path))
 			return nil // 跳过被排除的文件
 		}
 
@@ -417,32 +661,13 @@ func readManifest() (map[string]interface{}, error) {
 	return manifest, err
 }
 
-func getTargetsToBuild() ([]BuildTarget, error) {
-	if targetFlag == "" {
-		// 默认构建当前平台
-		return []BuildTarget{{runtime.GOOS, runtime.GOARCH}}, nil
-	}
-
-	if targetFlag == "all" {
-		// 构建所有平台
-		return buildTargets, nil
-	}
-
-	// 解析指定的目标
-	parts := strings.Split(targetFlag, "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("无效的目标格式: %s，应为 os/arch 格式", targetFlag)
-	}
-
-	os, arch := parts[0], parts[1]
-	// 验证目标是否支持
-	for _, target := range buildTargets {
-		if target.OS == os && target.Arch == arch {
-			return []BuildTarget{{os, arch}}, nil
-		}
+func writeManifest(manifest map[string]interface{}) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
 	}
 
-	return nil, fmt.Errorf("不支持的目标平台: %s/%s", os, arch)
+	return os.WriteFile("manifest.json", data, 0644)
 }
 
 func isValidProject() bool {
@@ -465,7 +690,7 @@ func loadBuildConfig() error {
 
 	err = json.Unmarshal(data, &buildConfig)
 	if err != nil {
-		return fmt.Errorf("解析配置文件失败: %w", err)
+		return fmt.Errorf(T("build.parse_config_failed"), err)
 	}
 
 	// 设置默认值
@@ -492,32 +717,3 @@ func isExcluded(path string) bool {
 	}
 	return false
 }
-
-// discoverCmdExecutables 自动发现cmd目录下的子目录，每个子目录代表一个可执行文件
-func discoverCmdExecutables() ([]string, error) {
-	cmdDir := "cmd"
-	if _, err := os.Stat(cmdDir); os.IsNotExist(err) {
-		return []string{}, nil // cmd目录不存在，返回空列表
-	}
-
-	entries, err := os.ReadDir(cmdDir)
-	if err != nil {
-		return nil, fmt.Errorf("读取cmd目录失败: %w", err)
-	}
-
-	var executables []string
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue // 跳过文件，只处理目录
-		}
-
-		execName := entry.Name()
-		// 检查子目录是否包含main.go文件
-		mainGoPath := filepath.Join(cmdDir, execName, "main.go")
-		if _, err := os.Stat(mainGoPath); err == nil {
-			executables = append(executables, execName)
-		}
-	}
-
-	return executables, nil
-}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildFailedTargetReportsFailure(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+	dir := t.TempDir()
+	chdir(t, dir)
+	resetExitCode(t)
+	writeFiles(t, dir, map[string]string{
+		"go.mod":        "module demo\n\ngo 1.21\n",
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"manifest.json": `{"name": "demo", "version": "1.0.0"}`,
+	})
+
+	defer func(target string, cache bool, config *BuildConfig) {
+		targetFlag, noCache, buildConfig = target, cache, config
+	}(targetFlag, noCache, buildConfig)
+	defer func(format string, writer io.Writer, command string, events []Event) {
+		outputFormat, eventWriter, eventCommand, collectedEvents = format, writer, command, events
+	}(outputFormat, eventWriter, eventCommand, collectedEvents)
+
+	var buf bytes.Buffer
+	outputFormat, eventWriter, eventCommand, collectedEvents = "json", &buf, "build", nil
+	// ios/arm64 只能使用 cgo 链接，在 CGO_ENABLED=0 下必定构建失败
+	targetFlag, noCache = "ios/arm64,linux/amd64", true
+
+	buildCmd.Run(buildCmd, nil)
+	finishOutput()
+
+	if exitCode == 0 {
+		t.Error("build with a failed target should exit with a non-zero code")
+	}
+	var doc struct {
+		Success bool    `json:"success"`
+		Events  []Event `json:"events"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json output: %v\n%s", err, buf.String())
+	}
+	if doc.Success {
+		t.Error("json output should report success: false")
+	}
+
+	failed := map[string]bool{}
+	for _, event := range doc.Events {
+		failed[event.Type+" "+event.Target] = true
+	}
+	for _, want := range []string{EventExecutableFailed + " ios/arm64", EventTargetFailed + " ios/arm64"} {
+		if !failed[want] {
+			t.Errorf("missing %s event", want)
+		}
+	}
+	if failed[EventTargetFailed+" linux/amd64"] {
+		t.Error("linux/amd64 should still build")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz")); err != nil {
+		t.Errorf("remaining targets should still be packaged: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dist", "demo_ios_arm64.tar.gz")); !os.IsNotExist(err) {
+		t.Error("failed target should not be packaged")
+	}
+}
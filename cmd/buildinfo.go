@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"runtime/debug"
+)
+
+// readBuildInfo 读取 Go 可执行文件内嵌的构建信息，不是 Go 可执行文件时返回错误
+func readBuildInfo(data []byte) (*debug.BuildInfo, error) {
+	return buildinfo.Read(bytes.NewReader(data))
+}
+
+// buildInfoModules 返回构建信息中的依赖模块及其版本，被 replace 的模块使用替换后的版本
+func buildInfoModules(info *debug.BuildInfo) map[string]string {
+	modules := make(map[string]string)
+	for _, dep := range info.Deps {
+		version := dep.Version
+		if dep.Replace != nil {
+			version = dep.Replace.Path + "@" + dep.Replace.Version
+			if dep.Replace.Version == "" {
+				version = dep.Replace.Path
+			}
+		}
+		modules[dep.Path] = version
+	}
+	return modules
+}
+
+// buildInfoSettings 返回构建设置（如 -ldflags、GOOS、vcs.revision）
+func buildInfoSettings(info *debug.BuildInfo) map[string]string {
+	settings := make(map[string]string)
+	for _, setting := range info.Settings {
+		settings[setting.Key] = setting.Value
+	}
+	return settings
+}
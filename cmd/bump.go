@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	bumpPreid        string
+	bumpUpdateSource bool
+	bumpSourceVar    string
+	bumpTag          bool
+	bumpTagPrefix    string
+)
+
+// bumpCmd 代表 bump 命令
+var bumpCmd = &cobra.Command{
+	Use:   "bump <major|minor|patch|prerelease|version>",
+	Short: "递增模块的语义化版本号",
+	Long: `按照语义化版本规则递增 manifest.json 中的模块版本号。
+参数可以是 major、minor、patch、prerelease，或者一个明确的版本号 (如 1.4.0)。
+可选地同步更新 Go 源码中的版本变量，并提交更改、创建 git 标签。`,
+	Example: `  dscli bump patch
+  dscli bump prerelease --preid beta
+  dscli bump 2.0.0 --update-source --tag`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := bumpVersion(args[0]); err != nil {
+			printError(T("bump.failed", err))
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bumpCmd)
+	bumpCmd.Flags().StringVar(&bumpPreid, "preid", "", "预发布标识，如 rc、beta (默认沿用当前标识或 rc)")
+	bumpCmd.Flags().BoolVar(&bumpUpdateSource, "update-source", false, "同步更新 Go 源码中的版本变量")
+	bumpCmd.Flags().StringVar(&bumpSourceVar, "source-var", "version", "Go 源码中的版本变量名")
+	bumpCmd.Flags().BoolVar(&bumpTag, "tag", false, "提交更改并创建 git 标签")
+	bumpCmd.Flags().StringVar(&bumpTagPrefix, "tag-prefix", "v", "git 标签前缀")
+}
+
+func bumpVersion(arg string) error {
+	if !isValidProject() {
+		return errors.New(T("common.not_project"))
+	}
+
+	manifest, err := readManifest()
+	if err != nil {
+		return fmt.Errorf(T("common.read_manifest_failed"), err)
+	}
+
+	oldVersion, _ := manifest["version"].(string)
+	current, err := parseSemVer(oldVersion)
+	if err != nil {
+		return fmt.Errorf(T("bump.invalid_version"), err)
+	}
+
+	var next *SemVer
+	switch arg {
+	case "major", "minor", "patch", "prerelease":
+		preid := bumpPreid
+		if preid == "" && len(current.Prerelease) == 0 {
+			preid = "rc"
+		}
+		if next, err = current.Bump(arg, preid); err != nil {
+			return err
+		}
+	default:
+		if next, err = parseSemVer(arg); err != nil {
+			return err
+		}
+	}
+	// 切换预发布标识可能得到更低的版本，如 1.0.0-rc.1 使用 --preid beta 得到 1.0.0-beta.0
+	if next.Compare(current) <= 0 {
+		return fmt.Errorf(T("bump.not_higher"), next, current)
+	}
+
+	newVersion := next.String()
+	// 提交和打标签的前提条件在修改任何文件之前检查，避免留下改了一半的工作区
+	if bumpTag {
+		if err := checkBumpTag(bumpTagPrefix + newVersion); err != nil {
+			return err
+		}
+	}
+
+	manifest["version"] = newVersion
+	if err := writeManifest(manifest); err != nil {
+		return fmt.Errorf(T("common.write_manifest_failed"), err)
+	}
+	logInfo(T("bump.version", oldVersion, newVersion))
+
+	changed := []string{"manifest.json"}
+	if bumpUpdateSource {
+		files, err := updateSourceVersion(bumpSourceVar, oldVersion, newVersion)
+		if err != nil {
+			return fmt.Errorf(T("bump.update_source_failed"), err)
+		}
+		for _, file := range files {
+			logInfo(T("bump.source_updated", file))
+		}
+		changed = append(changed, files...)
+	}
+
+	if bumpTag {
+		tag := bumpTagPrefix + newVersion
+		if _, err := runGit(".", append([]string{"add", "--"}, changed...)...); err != nil {
+			return err
+		}
+		if _, err := runGit(".", "commit", "-m", "Bump version to "+newVersion); err != nil {
+			return err
+		}
+		if _, err := runGit(".", "tag", tag); err != nil {
+			return err
+		}
+		logInfo(T("bump.tagged", tag))
+	}
+
+	logInfo(T("bump.done", newVersion))
+	return nil
+}
+
+// checkBumpTag 检查能否提交版本更改并创建标签：标签不能已存在，
+// 已跟踪的文件不能有未提交的修改，否则这些修改会混入版本提交或在提交时被遗漏
+func checkBumpTag(tag string) error {
+	exists, err := gitTagExists(tag)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf(T("bump.tag_exists"), tag)
+	}
+	modified, err := gitModifiedFiles()
+	if err != nil {
+		return err
+	}
+	if len(modified) > 0 {
+		return fmt.Errorf(T("bump.dirty_worktree"), strings.Join(modified, ", "))
+	}
+	return nil
+}
+
+// updateSourceVersion 将项目 Go 源码中形如 `version = "1.0.0"` 的版本变量更新为新版本，
+// 返回被修改的文件列表
+func updateSourceVersion(varName, oldVersion, newVersion string) ([]string, error) {
+	pattern, err := regexp.Compile(`(\b` + regexp.QuoteMeta(varName) + `\s*(?:string\s*)?=\s*")` +
+		regexp.QuoteMeta(oldVersion) + `(")`)
+	if err != nil {
+		return nil, err
+	}
+
+	skipDir := outputDirName()
+	var changed []string
+	err = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case ".git", "vendor", "node_modules", "bin", skipDir:
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		updated := pattern.ReplaceAll(data, []byte("${1}"+newVersion+"${2}"))
+		if string(updated) == string(data) {
+			return nil
+		}
+		if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+			return err
+		}
+		changed = append(changed, path)
+		return nil
+	})
+	return changed, err
+}
+
+// outputDirName 返回配置的输出目录名称，用于在扫描源码时跳过
+func outputDirName() string {
+	if err := loadBuildConfig(); err != nil || buildConfig == nil {
+		return "dist"
+	}
+	return filepath.Base(buildConfig.OutputDir)
+}
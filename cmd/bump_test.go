@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// setupBumpRepo 创建版本为 1.0.0 的项目并提交到新的 git 仓库，测试结束后恢复 bump 的标志
+func setupBumpRepo(t *testing.T) string {
+	t.Helper()
+	isolateGit(t)
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{
+		"manifest.json": `{"name": "demo", "version": "1.0.0"}`,
+		"main.go":       "package main\n\nvar version = \"1.0.0\"\n\nfunc main() {}\n",
+	})
+	if err := initGitRepo(dir, "Jane Doe <jane@example.com>"); err != nil {
+		t.Fatal(err)
+	}
+	gitOutput(t, dir, "config", "--global", "user.name", "Jane Doe")
+	gitOutput(t, dir, "config", "--global", "user.email", "jane@example.com")
+	gitOutput(t, dir, "config", "--global", "commit.gpgsign", "false")
+	gitOutput(t, dir, "config", "--global", "tag.gpgsign", "false")
+
+	saved := []string{bumpPreid, bumpSourceVar, bumpTagPrefix}
+	savedBools := []bool{bumpUpdateSource, bumpTag}
+	t.Cleanup(func() {
+		bumpPreid, bumpSourceVar, bumpTagPrefix = saved[0], saved[1], saved[2]
+		bumpUpdateSource, bumpTag = savedBools[0], savedBools[1]
+	})
+	bumpPreid, bumpSourceVar, bumpTagPrefix = "", "version", "v"
+	bumpUpdateSource, bumpTag = true, true
+	return dir
+}
+
+// manifestVersion 读取当前目录 manifest.json 中的版本号
+func manifestVersion(t *testing.T) string {
+	t.Helper()
+	manifest, err := readManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, _ := manifest["version"].(string)
+	return version
+}
+
+func TestBumpVersionWithTag(t *testing.T) {
+	dir := setupBumpRepo(t)
+
+	if err := bumpVersion("minor"); err != nil {
+		t.Fatal(err)
+	}
+	if v := manifestVersion(t); v != "1.1.0" {
+		t.Errorf("manifest version = %s, want 1.1.0", v)
+	}
+	if tag := gitOutput(t, dir, "describe", "--tags", "--exact-match"); tag != "v1.1.0" {
+		t.Errorf("HEAD tag = %q, want v1.1.0", tag)
+	}
+	if status := gitOutput(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("bump left uncommitted changes:\n%s", status)
+	}
+}
+
+func TestBumpVersionChecksBeforeWriting(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string)
+		want  string
+	}{
+		{
+			name:  "tag exists",
+			setup: func(t *testing.T, dir string) { gitOutput(t, dir, "tag", "v1.0.1") },
+			want:  T("bump.tag_exists", "v1.0.1"),
+		},
+		{
+			name: "dirty worktree",
+			setup: func(t *testing.T, dir string) {
+				writeFiles(t, dir, map[string]string{"main.go": "package main\n\nvar version = \"1.0.0\"\n\nfunc main() { println() }\n"})
+			},
+			want: T("bump.dirty_worktree", "main.go"),
+		},
+		{
+			name: "staged change",
+			setup: func(t *testing.T, dir string) {
+				writeFiles(t, dir, map[string]string{"README.md": "demo\n"})
+				gitOutput(t, dir, "add", "README.md")
+			},
+			want: T("bump.dirty_worktree", "README.md"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupBumpRepo(t)
+			tt.setup(t, dir)
+			head := gitOutput(t, dir, "rev-parse", "HEAD")
+
+			err := bumpVersion("patch")
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("bumpVersion error = %v, want %q", err, tt.want)
+			}
+			if v := manifestVersion(t); v != "1.0.0" {
+				t.Errorf("manifest version = %s, want it unchanged", v)
+			}
+			if data, _ := os.ReadFile("main.go"); !strings.Contains(string(data), `"1.0.0"`) {
+				t.Error("source version should be unchanged")
+			}
+			if got := gitOutput(t, dir, "rev-parse", "HEAD"); got != head {
+				t.Error("bump should not commit")
+			}
+		})
+	}
+}
+
+func TestBumpVersionUntrackedFilesAllowed(t *testing.T) {
+	dir := setupBumpRepo(t)
+	writeFiles(t, dir, map[string]string{"notes.txt": "scratch\n"})
+
+	if err := bumpVersion("patch"); err != nil {
+		t.Fatal(err)
+	}
+	if files := gitOutput(t, dir, "show", "--name-only", "--format=", "HEAD"); strings.Contains(files, "notes.txt") {
+		t.Errorf("untracked file was committed: %s", files)
+	}
+}
+
+func TestBumpVersionRejectsLowerVersion(t *testing.T) {
+	setupBumpRepo(t)
+	bumpTag = false
+	writeFiles(t, ".", map[string]string{"manifest.json": `{"name": "demo", "version": "1.0.0-rc.1"}`})
+
+	bumpPreid = "beta"
+	if err := bumpVersion("prerelease"); err == nil {
+		t.Error("1.0.0-rc.1 → 1.0.0-beta.0 should be rejected as a downgrade")
+	}
+	if v := manifestVersion(t); v != "1.0.0-rc.1" {
+		t.Errorf("manifest version = %s, want it unchanged", v)
+	}
+}
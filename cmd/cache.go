@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// cacheEnvVars 影响编译结果、需要计入缓存键的环境变量
+var cacheEnvVars = []string{"GOFLAGS", "GOEXPERIMENT", "GOAMD64", "GOARM", "GOARM64", "GO386", "GOMIPS", "GOPPC64", "GOWASM"}
+
+// BuildCache 内容寻址的构建缓存。
+// bin/<key> 保存单个可执行文件，key 由源码、go.mod/go.sum、编译参数和目标平台决定；
+// pkg/<key>/ 保存某个目标平台的模块包和 SBOM，key 还包括 manifest、资源文件和打包配置。
+type BuildCache struct {
+	Dir        string
+	sourceHash string
+	toolchain  string
+}
+
+var (
+	noCache          bool
+	cacheOlderThan   time.Duration
+	cacheCleanDryRun bool
+)
+
+// cacheCmd 代表 cache 命令
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "管理构建缓存",
+	Long: `管理 dscli build 使用的构建缓存。
+
+缓存位于用户缓存目录下的 dscli 子目录（可通过环境变量 DSCLI_CACHE_DIR 覆盖），
+按内容寻址：源码、go.mod/go.sum、编译参数、目标平台和资源文件都未变化的目标会直接复用上次的二进制文件和模块包。`,
+}
+
+// cacheInfoCmd 代表 cache info 命令
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "显示构建缓存的位置和大小",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := buildCacheDir()
+		if err != nil {
+			printError(T("cache.dir_failed", err))
+			return
+		}
+
+		binaries, binSize := cacheUsage(filepath.Join(dir, "bin"))
+		packages, pkgSize := cacheUsage(filepath.Join(dir, "pkg"))
+		logInfo(T("cache.dir", dir))
+		logInfo(T("cache.executables", binaries, formatSize(binSize)))
+		logInfo(T("cache.packages", packages, formatSize(pkgSize)))
+		logInfo(T("cache.total", formatSize(binSize+pkgSize)))
+	},
+}
+
+// cacheCleanCmd 代表 cache clean 命令
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "清理构建缓存",
+	Long:  `删除构建缓存中的所有条目，或使用 --older-than 只删除超过指定时间未使用的条目。`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := buildCacheDir()
+		if err != nil {
+			printError(T("cache.dir_failed", err))
+			return
+		}
+
+		removed, size, err := cleanBuildCache(dir, cacheOlderThan, cacheCleanDryRun)
+		if err != nil {
+			printError(T("cache.clean_failed", err))
+			return
+		}
+		if cacheCleanDryRun {
+			logInfo(T("cache.clean_dry_run", removed, formatSize(size)))
+			return
+		}
+		logInfo(T("cache.cleaned", removed, formatSize(size)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+	cacheCleanCmd.Flags().DurationVar(&cacheOlderThan, "older-than", 0, "只删除超过指定时间未使用的条目，如 168h")
+	cacheCleanCmd.Flags().BoolVar(&cacheCleanDryRun, "dry-run", false, "只显示将要删除的条目数量和大小")
+}
+
+// buildCacheDir 返回构建缓存目录，优先使用 DSCLI_CACHE_DIR
+func buildCacheDir() (string, error) {
+	if dir := os.Getenv("DSCLI_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dscli"), nil
+}
+
+// openBuildCache 计算项目源码的摘要并打开构建缓存，distDir 不计入源码
+func openBuildCache(distDir string) (*BuildCache, error) {
+	dir, err := buildCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceHash, err := hashSourceTree(".", distDir)
+	if err != nil {
+		return nil, fmt.Errorf(T("cache.hash_source_failed"), err)
+	}
+
+	// go.work 可能位于项目之外，其中的 replace 和模块列表同样影响构建结果
+	workHash, err := hashGoWork()
+	if err != nil {
+		return nil, fmt.Errorf(T("cache.hash_source_failed"), err)
+	}
+	sourceHash = cacheKey(sourceHash, workHash)
+
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return nil, fmt.Errorf(T("cache.go_version_failed"), err)
+	}
+
+	return &BuildCache{
+		Dir:        dir,
+		sourceHash: sourceHash,
+		toolchain:  strings.TrimSpace(string(output)),
+	}, nil
+}
+
+// hashSourceTree 计算项目目录中所有文件的路径、权限和内容摘要。
+// 只跳过项目根目录下的 .git、bin、node_modules 以及输出目录，子目录中的同名目录（如 internal/bin）仍计入；
+// manifest.json 的构建字段在每次构建时都会变化，单独处理。
+func hashSourceTree(root, distDir string) (string, error) {
+	skipDirs := map[string]bool{".git": true, "bin": true, "node_modules": true}
+	absDist, _ := filepath.Abs(distDir)
+	root = filepath.Clean(root)
+
+	h := sha256.New()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if absPath, _ := filepath.Abs(path); path != root && ((skipDirs[info.Name()] && filepath.Dir(path) == root) || absPath == absDist) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || path == filepath.Join(root, "manifest.json") {
+			return nil
+		}
+
+		digest, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%o\x00%s\n", filepath.ToSlash(path), info.Mode().Perm(), digest)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashGoWork 计算当前生效的 go.work 和 go.work.sum 的摘要，未使用工作区时返回空字符串
+func hashGoWork() (string, error) {
+	output, err := exec.Command("go", "env", "GOWORK").Output()
+	if err != nil {
+		return "", err
+	}
+	workFile := strings.TrimSpace(string(output))
+	if workFile == "" || workFile == "off" {
+		return "", nil
+	}
+
+	h := sha256.New()
+	for _, path := range []string{workFile, workFile + ".sum"} {
+		digest, err := fileSHA256(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", filepath.Base(path), digest)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheKey 将输入序列化后计算 sha256 作为缓存键
+func cacheKey(parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		data, _ := json.Marshal(part)
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// binaryKey 返回某个可执行文件在目标平台上的缓存键
+func (c *BuildCache) binaryKey(target BuildTarget, exe Executable) string {
+	env := make(map[string]string)
+	for _, name := range cacheEnvVars {
+		env[name] = os.Getenv(name)
+	}
+	return cacheKey("bin", Version, c.toolchain, c.sourceHash, env, buildVersion, target, exe)
+}
+
+// packageKey 返回目标平台模块包的缓存键，包括所有可执行文件的缓存键、manifest、资源文件和打包配置
+func (c *BuildCache) packageKey(target BuildTarget, executables []Executable) (string, error) {
+	var binaryKeys []string
+	for _, exe := range executables {
+		binaryKeys = append(binaryKeys, c.binaryKey(target, exe))
+	}
+
+	manifest, err := readManifest()
+	if err != nil {
+		return "", err
+	}
+	for _, field := range []string{"build_date", "os", "arch"} {
+		delete(manifest, field)
+	}
+	if buildVersion != "" {
+		manifest["version"] = buildVersion
+	}
+
+	assets, err := parseAssets(buildConfig.Assets)
+	if err != nil {
+		return "", err
+	}
+	assetHashes := make(map[string]string)
+	for _, asset := range assets {
+		digest, err := hashAsset(asset.Source)
+		if err != nil {
+			return "", err
+		}
+		assetHashes[asset.Source+"\x00"+asset.Output] = digest
+	}
+
+	return cacheKey("pkg", binaryKeys, manifest, assetHashes, buildConfig.Excludes, sbomEnabled(), buildConfig.Hooks), nil
+}
+
+// hashAsset 计算资源文件或目录的摘要，不存在的资源记为空
+func hashAsset(source string) (string, error) {
+	info, err := os.Stat(source)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return fileSHA256(source)
+	}
+	return hashSourceTree(source, "")
+}
+
+// restoreBinary 从缓存中复制可执行文件到 dest，未命中时返回 false
+func (c *BuildCache) restoreBinary(key, dest string) bool {
+	src := filepath.Join(c.Dir, "bin", key)
+	if err := copyCacheFile(src, dest, 0755); err != nil {
+		return false
+	}
+	touchCacheEntry(src)
+	return true
+}
+
+// storeBinary 将刚编译的可执行文件保存到缓存
+func (c *BuildCache) storeBinary(key, src string) error {
+	dir := filepath.Join(c.Dir, "bin")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, key+".tmp")
+	if err := copyCacheFile(src, tmp, 0755); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, key))
+}
+
+// restorePackage 从缓存中复制模块包及其附属文件到 distDir，未命中时返回 false
+func (c *BuildCache) restorePackage(key, distDir string) bool {
+	entryDir := filepath.Join(c.Dir, "pkg", key)
+	entries, err := os.ReadDir(entryDir)
+	if err != nil || len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if err := copyCacheFile(filepath.Join(entryDir, entry.Name()), filepath.Join(distDir, entry.Name()), 0644); err != nil {
+			return false
+		}
+	}
+	touchCacheEntry(entryDir)
+	return true
+}
+
+// storePackage 将模块包及其附属文件保存到缓存
+func (c *BuildCache) storePackage(key string, files []string) error {
+	pkgDir := filepath.Join(c.Dir, "pkg")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(pkgDir, key+".tmp-")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := copyCacheFile(file, filepath.Join(tmpDir, filepath.Base(file)), 0644); err != nil {
+			os.RemoveAll(tmpDir)
+			return err
+		}
+	}
+
+	entryDir := filepath.Join(pkgDir, key)
+	os.RemoveAll(entryDir)
+	if err := os.Rename(tmpDir, entryDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+	return os.Chmod(entryDir, 0755)
+}
+
+// copyCacheFile 复制文件并设置权限
+func copyCacheFile(src, dest string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(dest, perm)
+}
+
+// touchCacheEntry 更新缓存条目的修改时间，供 cache clean --older-than 判断是否仍在使用
+func touchCacheEntry(path string) {
+	now := time.Now()
+	os.Chtimes(path, now, now)
+}
+
+// cacheUsage 统计缓存子目录中的条目数量和总大小
+func cacheUsage(dir string) (int, int64) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0
+	}
+	var size int64
+	for _, entry := range entries {
+		size += pathSize(filepath.Join(dir, entry.Name()))
+	}
+	return len(entries), size
+}
+
+// pathSize 返回文件或目录的总大小
+func pathSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// cleanBuildCache 删除缓存条目，olderThan 大于 0 时只删除超过该时间未使用的条目
+func cleanBuildCache(dir string, olderThan time.Duration, dryRun bool) (int, int64, error) {
+	var paths []string
+	for _, sub := range []string{"bin", "pkg"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			if olderThan > 0 && time.Since(info.ModTime()) < olderThan {
+				continue
+			}
+			paths = append(paths, filepath.Join(dir, sub, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	var size int64
+	for _, path := range paths {
+		size += pathSize(path)
+		if dryRun {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(paths), size, nil
+}
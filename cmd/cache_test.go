@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashSourceTreeSkipsRootDirsOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.go":             "package main\n",
+		"internal/bin/gen.go": "package bin\n",
+		"bin/app":             "binary",
+		"dist/app.tar.gz":     "package",
+	})
+	hash := func() string {
+		t.Helper()
+		sum, err := hashSourceTree(dir, filepath.Join(dir, "dist"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+	base := hash()
+
+	// 根目录下的 bin 和输出目录不影响缓存键
+	writeFiles(t, dir, map[string]string{"bin/app": "rebuilt", "dist/app.tar.gz": "repacked", ".git/HEAD": "ref"})
+	if got := hash(); got != base {
+		t.Error("changes under bin/, .git/ or the output directory should not change the source hash")
+	}
+
+	// 子目录中的同名目录属于源码
+	writeFiles(t, dir, map[string]string{"internal/bin/gen.go": "package bin // changed\n"})
+	if got := hash(); got == base {
+		t.Error("changes under internal/bin/ should change the source hash")
+	}
+}
+
+func TestHashGoWork(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.work":     "go 1.21\n\nuse ./app\n",
+		"go.work.sum": "",
+	})
+
+	t.Setenv("GOWORK", "off")
+	if sum, err := hashGoWork(); err != nil || sum != "" {
+		t.Fatalf("hashGoWork with GOWORK=off = %q, %v", sum, err)
+	}
+
+	t.Setenv("GOWORK", filepath.Join(dir, "go.work"))
+	base, err := hashGoWork()
+	if err != nil || base == "" {
+		t.Fatalf("hashGoWork = %q, %v", base, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.work.sum"), []byte("example.com/m v1.0.0 h1:abc=\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if sum, err := hashGoWork(); err != nil || sum == base {
+		t.Errorf("changing go.work.sum should change the hash (%v)", err)
+	}
+}
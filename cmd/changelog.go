@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogFile         string
+	changelogAll          bool
+	changelogTagPrefix    string
+	changelogReleaseNotes bool
+	changelogManifest     bool
+)
+
+// conventionalPattern 匹配 Conventional Commits 格式的提交标题，如 "feat(api)!: add endpoint"
+var conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// changelogSections 变更类型及其在 CHANGELOG 中的标题，按输出顺序排列
+var changelogSections = []struct {
+	Type  string
+	Title string
+}{
+	{"breaking", "Breaking Changes"},
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"refactor", "Code Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"test", "Tests"},
+	{"style", "Styles"},
+	{"chore", "Chores"},
+	{"other", "Other Changes"},
+}
+
+// changelogCommit 一次提交解析后的信息
+type changelogCommit struct {
+	Hash     string
+	Type     string
+	Scope    string
+	Subject  string
+	Breaking bool
+}
+
+// changelogRelease 一个版本的变更记录
+type changelogRelease struct {
+	Version string
+	Date    string
+	Commits []changelogCommit
+}
+
+// changelogCmd 代表 changelog 命令
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "根据 git 历史生成 CHANGELOG.md",
+	Long: `读取本地 git 仓库中标签之间的提交，按 Conventional Commits 类型分组，
+生成或更新 CHANGELOG.md。默认只生成 manifest.json 中当前版本的变更记录，
+使用 --all 根据所有版本标签重新生成完整的 CHANGELOG。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateChangelog(); err != nil {
+			printError(T("changelog.failed", err))
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+	changelogCmd.Flags().StringVarP(&changelogFile, "file", "f", "CHANGELOG.md", "CHANGELOG 文件路径")
+	changelogCmd.Flags().BoolVar(&changelogAll, "all", false, "根据所有版本标签重新生成完整的 CHANGELOG")
+	changelogCmd.Flags().StringVar(&changelogTagPrefix, "tag-prefix", "v", "版本标签前缀")
+	changelogCmd.Flags().BoolVar(&changelogReleaseNotes, "release-notes", false, "将当前版本的变更记录写入 RELEASE_NOTES.md")
+	changelogCmd.Flags().BoolVar(&changelogManifest, "manifest", false, "将当前版本的变更记录写入项目源码中 manifest.json 的 release_notes 字段（会修改该文件，构建时随之打包）")
+}
+
+func generateChangelog() error {
+	if !isValidProject() {
+		return errors.New(T("common.not_project"))
+	}
+
+	manifest, err := readManifest()
+	if err != nil {
+		return fmt.Errorf(T("common.read_manifest_failed"), err)
+	}
+	currentVersion, _ := manifest["version"].(string)
+	if currentVersion == "" {
+		return errors.New(T("changelog.no_version"))
+	}
+
+	tags, err := versionTags(changelogTagPrefix)
+	if err != nil {
+		return err
+	}
+
+	var releases []changelogRelease
+	if changelogAll {
+		for i, tag := range tags {
+			prev := ""
+			if i > 0 {
+				prev = tags[i-1]
+			}
+			release, err := collectRelease(prev, tag, strings.TrimPrefix(tag, changelogTagPrefix))
+			if err != nil {
+				return err
+			}
+			releases = append(releases, release)
+		}
+	}
+
+	// 当前版本: 如果已打标签则使用标签区间，否则使用最新标签到 HEAD 的提交
+	current, err := currentRelease(tags, currentVersion)
+	if err != nil {
+		return err
+	}
+	if !changelogAll || !hasTag(tags, changelogTagPrefix+currentVersion) {
+		releases = append(releases, current)
+	}
+
+	existing := ""
+	if data, err := os.ReadFile(changelogFile); err == nil && !changelogAll {
+		existing = string(data)
+	}
+	content := existing
+	for _, release := range releases {
+		content = upsertChangelogSection(content, release.Version, renderRelease(release))
+	}
+	content = strings.TrimRight(content, "\n") + "\n"
+	if err := os.WriteFile(changelogFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf(T("changelog.write_failed"), changelogFile, err)
+	}
+	logInfo(T("changelog.updated", changelogFile, len(releases)))
+
+	notes := strings.TrimRight(renderReleaseNotes(current), "\n") + "\n"
+	if changelogReleaseNotes {
+		if err := os.WriteFile("RELEASE_NOTES.md", []byte(notes), 0644); err != nil {
+			return fmt.Errorf(T("changelog.write_notes_failed"), err)
+		}
+		logInfo(T("changelog.notes_written"))
+	}
+	if changelogManifest {
+		manifest["release_notes"] = notes
+		if err := writeManifest(manifest); err != nil {
+			return fmt.Errorf(T("common.write_manifest_failed"), err)
+		}
+		logInfo(T("changelog.manifest_updated"))
+	}
+
+	return nil
+}
+
+// versionTags 返回 HEAD 可达的版本标签，按语义化版本从低到高排序
+func versionTags(prefix string) ([]string, error) {
+	out, err := runGit(".", "tag", "--merged", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	type versionTag struct {
+		tag     string
+		version *SemVer
+	}
+	var tags []versionTag
+	for _, tag := range strings.Fields(out) {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		v, err := parseSemVer(strings.TrimPrefix(tag, prefix))
+		if err != nil {
+			continue
+		}
+		tags = append(tags, versionTag{tag, v})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].version.Compare(tags[j].version) < 0
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result, nil
+}
+
+// currentRelease 收集当前版本的变更记录
+func currentRelease(tags []string, version string) (changelogRelease, error) {
+	tag := changelogTagPrefix + version
+	for i, t := range tags {
+		if t != tag {
+			continue
+		}
+		prev := ""
+		if i > 0 {
+			prev = tags[i-1]
+		}
+		return collectRelease(prev, tag, version)
+	}
+
+	prev := ""
+	if len(tags) > 0 {
+		prev = tags[len(tags)-1]
+	}
+	return collectRelease(prev, "HEAD", version)
+}
+
+// collectRelease 收集 from (不含) 到 to 之间的提交，from 为空表示从第一个提交开始
+func collectRelease(from, to, version string) (changelogRelease, error) {
+	release := changelogRelease{Version: version, Date: time.Now().Format("2006-01-02")}
+	if to != "HEAD" {
+		if date, err := runGit(".", "log", "-1", "--format=%cs", to); err == nil && date != "" {
+			release.Date = date
+		}
+	}
+
+	rangeSpec := to
+	if from != "" {
+		rangeSpec = from + ".." + to
+	}
+	out, err := runGit(".", "log", "--no-merges", "--format=%h%x1f%s%x1f%b%x1e", rangeSpec)
+	if err != nil {
+		return release, err
+	}
+
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		body := ""
+		if len(fields) == 3 {
+			body = fields[2]
+		}
+		release.Commits = append(release.Commits, parseConventionalCommit(fields[0], fields[1], body))
+	}
+	return release, nil
+}
+
+// parseConventionalCommit 解析 Conventional Commits 格式的提交，不符合格式的归入 other
+func parseConventionalCommit(hash, subject, body string) changelogCommit {
+	commit := changelogCommit{Hash: hash, Type: "other", Subject: subject}
+	if m := conventionalPattern.FindStringSubmatch(subject); m != nil {
+		commit.Type = strings.ToLower(m[1])
+		commit.Scope = m[2]
+		commit.Breaking = m[3] == "!"
+		commit.Subject = m[4]
+	}
+	if strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:") {
+		commit.Breaking = true
+	}
+
+	known := false
+	for _, section := range changelogSections {
+		if section.Type == commit.Type {
+			known = true
+			break
+		}
+	}
+	if !known {
+		commit.Type = "other"
+	}
+	return commit
+}
+
+// renderRelease 生成一个版本的 CHANGELOG 段落
+func renderRelease(release changelogRelease) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## [%s] - %s\n\n", release.Version, release.Date)
+	b.WriteString(renderReleaseNotes(release))
+	return b.String()
+}
+
+// renderReleaseNotes 按类型分组生成变更列表
+func renderReleaseNotes(release changelogRelease) string {
+	var b strings.Builder
+	for _, section := range changelogSections {
+		var lines []string
+		for _, commit := range release.Commits {
+			if section.Type == "breaking" && !commit.Breaking {
+				continue
+			}
+			if section.Type != "breaking" && (commit.Breaking || commit.Type != section.Type) {
+				continue
+			}
+			line := "- "
+			if commit.Scope != "" {
+				line += fmt.Sprintf("**%s:** ", commit.Scope)
+			}
+			lines = append(lines, fmt.Sprintf("%s%s (%s)", line, commit.Subject, commit.Hash))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "### %s\n\n%s\n\n", section.Title, strings.Join(lines, "\n"))
+	}
+	if b.Len() == 0 {
+		b.WriteString("No changes.\n\n")
+	}
+	return b.String()
+}
+
+// upsertChangelogSection 在 CHANGELOG 中替换指定版本的段落，不存在时插入到所有版本之前
+func upsertChangelogSection(content, version, section string) string {
+	const header = "# Changelog\n\nAll notable changes to this module are documented in this file.\n\n"
+	if strings.TrimSpace(content) == "" {
+		content = header
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	start, end := -1, len(lines)
+	firstSection := -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## [") {
+			continue
+		}
+		if firstSection < 0 {
+			firstSection = i
+		}
+		if start >= 0 {
+			end = i
+			break
+		}
+		if strings.HasPrefix(line, "## ["+version+"]") {
+			start = i
+		}
+	}
+
+	switch {
+	case start >= 0:
+		return strings.Join(lines[:start], "") + section + strings.Join(lines[end:], "")
+	case firstSection >= 0:
+		return strings.Join(lines[:firstSection], "") + section + strings.Join(lines[firstSection:], "")
+	default:
+		return strings.TrimRight(content, "\n") + "\n\n" + section
+	}
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
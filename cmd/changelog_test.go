@@ -0,0 +1,96 @@
+package cmd
+
+import "testing"
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		subject, body string
+		want          changelogCommit
+	}{
+		{
+			subject: "feat(api)!: drop the v1 endpoints",
+			want:    changelogCommit{Hash: "abc1234", Type: "feat", Scope: "api", Breaking: true, Subject: "drop the v1 endpoints"},
+		},
+		{
+			subject: "fix: handle empty input",
+			body:    "Longer description.\n\nBREAKING CHANGE: input is now required",
+			want:    changelogCommit{Hash: "abc1234", Type: "fix", Breaking: true, Subject: "handle empty input"},
+		},
+		{
+			subject: "Fix(parser): trim spaces",
+			want:    changelogCommit{Hash: "abc1234", Type: "fix", Scope: "parser", Subject: "trim spaces"},
+		},
+		{
+			subject: "wip: half done",
+			want:    changelogCommit{Hash: "abc1234", Type: "other", Subject: "half done"},
+		},
+		{
+			subject: "Update README",
+			want:    changelogCommit{Hash: "abc1234", Type: "other", Subject: "Update README"},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseConventionalCommit("abc1234", tt.subject, tt.body); got != tt.want {
+			t.Errorf("parseConventionalCommit(%q) = %+v, want %+v", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestUpsertChangelogSection(t *testing.T) {
+	const existing = "# Changelog\n\nIntro.\n\n" +
+		"## [1.1.0] - 2024-02-01\n\n### Features\n\n- old entry (aaa)\n\n" +
+		"## [1.0.0] - 2024-01-01\n\n### Features\n\n- first (bbb)\n\n"
+
+	tests := []struct {
+		name, content, version, section, want string
+	}{
+		{
+			name:    "replace existing section",
+			content: existing,
+			version: "1.1.0",
+			section: "## [1.1.0] - 2024-03-01\n\n### Bug Fixes\n\n- new entry (ccc)\n\n",
+			want: "# Changelog\n\nIntro.\n\n" +
+				"## [1.1.0] - 2024-03-01\n\n### Bug Fixes\n\n- new entry (ccc)\n\n" +
+				"## [1.0.0] - 2024-01-01\n\n### Features\n\n- first (bbb)\n\n",
+		},
+		{
+			name:    "replace last section",
+			content: existing,
+			version: "1.0.0",
+			section: "## [1.0.0] - 2024-01-01\n\nNo changes.\n\n",
+			want: "# Changelog\n\nIntro.\n\n" +
+				"## [1.1.0] - 2024-02-01\n\n### Features\n\n- old entry (aaa)\n\n" +
+				"## [1.0.0] - 2024-01-01\n\nNo changes.\n\n",
+		},
+		{
+			name:    "insert above the first section",
+			content: existing,
+			version: "1.2.0",
+			section: "## [1.2.0] - 2024-04-01\n\nNo changes.\n\n",
+			want: "# Changelog\n\nIntro.\n\n" +
+				"## [1.2.0] - 2024-04-01\n\nNo changes.\n\n" +
+				"## [1.1.0] - 2024-02-01\n\n### Features\n\n- old entry (aaa)\n\n" +
+				"## [1.0.0] - 2024-01-01\n\n### Features\n\n- first (bbb)\n\n",
+		},
+		{
+			name:    "version prefix is not a match",
+			content: "## [1.0.10] - 2024-01-01\n\n- x\n",
+			version: "1.0.1",
+			section: "## [1.0.1] - 2024-01-02\n\n- y\n\n",
+			want:    "## [1.0.1] - 2024-01-02\n\n- y\n\n## [1.0.10] - 2024-01-01\n\n- x\n",
+		},
+		{
+			name:    "new file",
+			content: "",
+			version: "0.1.0",
+			section: "## [0.1.0] - 2024-01-01\n\nNo changes.\n\n",
+			want: "# Changelog\n\nAll notable changes to this module are documented in this file.\n\n" +
+				"## [0.1.0] - 2024-01-01\n\nNo changes.\n\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := upsertChangelogSection(tt.content, tt.version, tt.section); got != tt.want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tt.name, got, tt.want)
+		}
+	}
+}
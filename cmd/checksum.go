@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// checksumFileName 输出目录中的校验和文件名，格式与 sha256sum 的输出一致
+const checksumFileName = "checksums.txt"
+
+// fileSHA256 计算文件的 SHA-256 摘要，返回十六进制字符串
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeChecksums 为目录中的所有包生成 checksums.txt，删除过期的 checksums.txt.sig，返回校验和文件路径
+func writeChecksums(distDir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(distDir, "*.tar.gz"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, file := range files {
+		sum, err := fileSHA256(file)
+		if err != nil {
+			return "", fmt.Errorf(T("checksum.hash_failed"), file, err)
+		}
+		fmt.Fprintf(&b, "%s  %s\n", sum, filepath.Base(file))
+	}
+
+	// 重新生成的校验和文件没有签名，删除旧的签名，需要时由 --sign 重新签名
+	checksumPath := filepath.Join(distDir, checksumFileName)
+	if err := os.Remove(checksumPath + signatureSuffix); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.WriteFile(checksumPath, []byte(b.String()), 0644); err != nil {
+		return "", err
+	}
+	return checksumPath, nil
+}
+
+// readChecksums 读取 sha256sum 格式的校验和文件，返回文件名到摘要的映射
+func readChecksums(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	return sums, scanner.Err()
+}
+
+// verifyChecksum 使用包所在目录的 checksums.txt 校验包的完整性。
+// 校验和文件不存在时返回 found=false。
+func verifyChecksum(packagePath string) (found bool, err error) {
+	sums, err := readChecksums(filepath.Join(filepath.Dir(packagePath), checksumFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	expected, ok := sums[filepath.Base(packagePath)]
+	if !ok {
+		return false, nil
+	}
+	actual, err := fileSHA256(packagePath)
+	if err != nil {
+		return true, err
+	}
+	if actual != expected {
+		return true, fmt.Errorf(T("checksum.mismatch"), filepath.Base(packagePath), expected, actual)
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanDryRun bool
+	cleanCache  bool
+)
+
+// cleanCmd 代表 clean 命令
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "删除构建输出目录",
+	Long: `删除 .dscli.json 中 output_dir 指定的构建输出目录（默认为 dist）。
+
+为防止误删，输出目录必须位于项目目录内、不能是项目根目录本身，且不能包含 manifest.json。
+使用 --cache 同时清空构建缓存。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isValidProject() {
+			printError(T("common.error_not_project"))
+			return
+		}
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+
+		dir := outputDir()
+		if err := checkCleanDir(dir); err != nil {
+			printError(T("clean.refused", dir, err))
+			return
+		}
+
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			logInfo(T("clean.missing", dir))
+		} else if cleanDryRun {
+			logInfo(T("clean.dry_run", dir, formatSize(pathSize(dir))))
+		} else {
+			if err := os.RemoveAll(dir); err != nil {
+				printError(T("clean.remove_failed", err))
+				return
+			}
+			logInfo(T("clean.removed", dir))
+		}
+
+		if cleanCache {
+			cacheDir, err := buildCacheDir()
+			if err != nil {
+				printError(T("cache.dir_failed", err))
+				return
+			}
+			removed, size, err := cleanBuildCache(cacheDir, 0, cleanDryRun)
+			if err != nil {
+				printError(T("cache.clean_failed", err))
+				return
+			}
+			if cleanDryRun {
+				logInfo(T("cache.clean_dry_run", removed, formatSize(size)))
+				return
+			}
+			logInfo(T("cache.cleaned", removed, formatSize(size)))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "只显示将要删除的内容")
+	cleanCmd.Flags().BoolVar(&cleanCache, "cache", false, "同时清空构建缓存")
+}
+
+// checkCleanDir 检查目录是否可以安全删除：必须位于当前项目目录内且不是项目根目录，
+// 不能包含 manifest.json。符号链接会先解析，防止指向项目外的目录。
+func checkCleanDir(dir string) error {
+	root, err := filepath.Abs(".")
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	target, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return fmt.Errorf(T("clean.rel_failed"), err)
+	}
+	if rel == "." {
+		return errors.New(T("clean.is_root"))
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return errors.New(T("clean.outside"))
+	}
+	if _, err := os.Stat(filepath.Join(target, "manifest.json")); err == nil {
+		return errors.New(T("clean.has_manifest"))
+	}
+	return nil
+}
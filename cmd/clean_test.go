@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckCleanDir(t *testing.T) {
+	outside := t.TempDir()
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{
+		"manifest.json":          "{}",
+		"dist/app.tar.gz":        "package",
+		"nested/manifest.json":   "{}",
+		"nested/bin/placeholder": "",
+	})
+	if err := os.Symlink(outside, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir  string
+		want string // 期望的错误消息键，空表示允许删除
+	}{
+		{dir: "dist"},
+		{dir: "missing"},
+		{dir: ".", want: "clean.is_root"},
+		{dir: "dist/..", want: "clean.is_root"},
+		{dir: "..", want: "clean.outside"},
+		{dir: outside, want: "clean.outside"},
+		{dir: "link", want: "clean.outside"},
+		{dir: "nested", want: "clean.has_manifest"},
+	}
+	for _, tt := range tests {
+		err := checkCleanDir(tt.dir)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("checkCleanDir(%q) = %v, want nil", tt.dir, err)
+		case tt.want != "" && (err == nil || err.Error() != T(tt.want)):
+			t.Errorf("checkCleanDir(%q) = %v, want %q", tt.dir, err, T(tt.want))
+		}
+	}
+}
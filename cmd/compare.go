@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// PackageComparison 两个模块包的差异
+type PackageComparison struct {
+	A         string                `json:"a"`
+	B         string                `json:"b"`
+	Files     []FileDifference      `json:"files"`
+	Manifest  []FieldDifference     `json:"manifest"`
+	BuildInfo []BuildInfoDifference `json:"build_info"`
+	Unchanged int                   `json:"unchanged_files"`
+}
+
+// FileDifference 包中一个文件的差异
+type FileDifference struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"` // added、removed 或 changed
+	SizeA   int64  `json:"size_a,omitempty"`
+	SizeB   int64  `json:"size_b,omitempty"`
+	ModeA   string `json:"mode_a,omitempty"`
+	ModeB   string `json:"mode_b,omitempty"`
+	SHA256A string `json:"sha256_a,omitempty"`
+	SHA256B string `json:"sha256_b,omitempty"`
+}
+
+// FieldDifference manifest 字段或构建设置的差异，缺失的值为 null
+type FieldDifference struct {
+	Field string      `json:"field"`
+	A     interface{} `json:"a"`
+	B     interface{} `json:"b"`
+}
+
+// BuildInfoDifference 一个可执行文件的 Go 构建信息差异
+type BuildInfoDifference struct {
+	Executable string            `json:"executable"`
+	GoVersionA string            `json:"go_version_a,omitempty"`
+	GoVersionB string            `json:"go_version_b,omitempty"`
+	MainA      string            `json:"main_a,omitempty"`
+	MainB      string            `json:"main_b,omitempty"`
+	Modules    []FieldDifference `json:"modules,omitempty"`
+	Settings   []FieldDifference `json:"settings,omitempty"`
+}
+
+// compareCmd 代表 compare 命令
+var compareCmd = &cobra.Command{
+	Use:   "compare <a.tar.gz> <b.tar.gz>",
+	Short: "比较两个模块包的内容差异",
+	Long: `比较两个模块包的文件列表、大小、权限、摘要、manifest.json 字段，
+以及每个可执行文件内嵌的 Go 构建信息（Go 版本、依赖模块版本和构建设置）。`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := comparePackages(args[0], args[1])
+		if err != nil {
+			printError(T("compare.failed", err))
+			return
+		}
+
+		printComparison(result)
+		emitEvent(Event{Type: EventCompareResult, Data: result})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+}
+
+func comparePackages(aPath, bPath string) (*PackageComparison, error) {
+	aEntries, err := readPackageEntries(aPath)
+	if err != nil {
+		return nil, err
+	}
+	bEntries, err := readPackageEntries(bPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PackageComparison{
+		A:         aPath,
+		B:         bPath,
+		Files:     []FileDifference{},
+		Manifest:  []FieldDifference{},
+		BuildInfo: []BuildInfoDifference{},
+	}
+
+	aFiles := regularEntries(aEntries)
+	bFiles := regularEntries(bEntries)
+
+	// 文件列表、大小、权限和摘要
+	for _, name := range unionKeys(aFiles, bFiles) {
+		a, inA := aFiles[name]
+		b, inB := bFiles[name]
+		diff := FileDifference{Path: name}
+		if inA {
+			diff.SizeA, diff.ModeA, diff.SHA256A = int64(len(a.Data)), fileModeString(a.Header), sha256Hex(a.Data)
+		}
+		if inB {
+			diff.SizeB, diff.ModeB, diff.SHA256B = int64(len(b.Data)), fileModeString(b.Header), sha256Hex(b.Data)
+		}
+
+		switch {
+		case !inA:
+			diff.Status = "added"
+		case !inB:
+			diff.Status = "removed"
+		case diff.SHA256A != diff.SHA256B || diff.ModeA != diff.ModeB:
+			diff.Status = "changed"
+		default:
+			result.Unchanged++
+			continue
+		}
+		result.Files = append(result.Files, diff)
+	}
+
+	// manifest 字段
+	aManifest, err := entryManifest(aFiles, aPath)
+	if err != nil {
+		return nil, err
+	}
+	bManifest, err := entryManifest(bFiles, bPath)
+	if err != nil {
+		return nil, err
+	}
+	result.Manifest = compareFields(aManifest, bManifest)
+
+	// 可执行文件的 Go 构建信息
+	for _, name := range unionKeys(aFiles, bFiles) {
+		a, inA := aFiles[name]
+		b, inB := bFiles[name]
+		if !inA || !inB {
+			continue
+		}
+		aInfo, aErr := readBuildInfo(a.Data)
+		bInfo, bErr := readBuildInfo(b.Data)
+		if aErr != nil || bErr != nil {
+			continue // 不是 Go 可执行文件
+		}
+
+		diff := BuildInfoDifference{
+			Executable: name,
+			Modules:    compareStringMaps(buildInfoModules(aInfo), buildInfoModules(bInfo)),
+			Settings:   compareStringMaps(buildInfoSettings(aInfo), buildInfoSettings(bInfo)),
+		}
+		if aInfo.GoVersion != bInfo.GoVersion {
+			diff.GoVersionA, diff.GoVersionB = aInfo.GoVersion, bInfo.GoVersion
+		}
+		if aInfo.Main.Version != bInfo.Main.Version {
+			diff.MainA, diff.MainB = aInfo.Main.Version, bInfo.Main.Version
+		}
+		if diff.GoVersionA != "" || diff.MainA != "" || diff.MainB != "" || len(diff.Modules) > 0 || len(diff.Settings) > 0 {
+			result.BuildInfo = append(result.BuildInfo, diff)
+		}
+	}
+
+	return result, nil
+}
+
+func printComparison(result *PackageComparison) {
+	logInfo(T("compare.header", result.A, result.B))
+
+	logInfo(T("compare.files", result.Unchanged))
+	if len(result.Files) == 0 {
+		logInfo(T("compare.no_diff"))
+	}
+	for _, diff := range result.Files {
+		switch diff.Status {
+		case "added":
+			logInfo(fmt.Sprintf("  + %s (%s, %s)", diff.Path, formatSize(diff.SizeB), diff.ModeB))
+		case "removed":
+			logInfo(fmt.Sprintf("  - %s (%s, %s)", diff.Path, formatSize(diff.SizeA), diff.ModeA))
+		default:
+			var changes []string
+			if diff.SizeA != diff.SizeB {
+				changes = append(changes, T("compare.size", formatSize(diff.SizeA), formatSize(diff.SizeB)))
+			}
+			if diff.ModeA != diff.ModeB {
+				changes = append(changes, T("compare.mode", diff.ModeA, diff.ModeB))
+			}
+			if diff.SHA256A != diff.SHA256B {
+				changes = append(changes, T("compare.digest", diff.SHA256A[:12], diff.SHA256B[:12]))
+			}
+			logInfo(fmt.Sprintf("  ~ %s  %s", diff.Path, strings.Join(changes, "  ")))
+		}
+	}
+
+	logInfo("\nmanifest.json:")
+	if len(result.Manifest) == 0 {
+		logInfo(T("compare.no_diff"))
+	}
+	printFieldDifferences("  ", result.Manifest)
+
+	for _, diff := range result.BuildInfo {
+		logInfo(T("compare.buildinfo", diff.Executable))
+		if diff.GoVersionA != "" {
+			logInfo(T("compare.go_version", diff.GoVersionA, diff.GoVersionB))
+		}
+		if diff.MainA != "" || diff.MainB != "" {
+			logInfo(T("compare.main_version", diff.MainA, diff.MainB))
+		}
+		if len(diff.Modules) > 0 {
+			logInfo(T("compare.modules"))
+			printFieldDifferences("    ", diff.Modules)
+		}
+		if len(diff.Settings) > 0 {
+			logInfo(T("compare.settings"))
+			printFieldDifferences("    ", diff.Settings)
+		}
+	}
+}
+
+func printFieldDifferences(indent string, diffs []FieldDifference) {
+	for _, diff := range diffs {
+		switch {
+		case diff.A == nil:
+			logInfo(fmt.Sprintf("%s+ %s: %s", indent, diff.Field, formatValue(diff.B)))
+		case diff.B == nil:
+			logInfo(fmt.Sprintf("%s- %s: %s", indent, diff.Field, formatValue(diff.A)))
+		default:
+			logInfo(fmt.Sprintf("%s~ %s: %s → %s", indent, diff.Field, formatValue(diff.A), formatValue(diff.B)))
+		}
+	}
+}
+
+// regularEntries 返回包中普通文件条目，以规范化路径为键
+func regularEntries(entries []packageEntry) map[string]packageEntry {
+	files := make(map[string]packageEntry)
+	for _, entry := range entries {
+		if entry.Header.Typeflag == tar.TypeReg || entry.Header.Typeflag == tar.TypeRegA {
+			files[path.Clean(entry.Header.Name)] = entry
+		}
+	}
+	return files
+}
+
+func entryManifest(files map[string]packageEntry, packagePath string) (map[string]interface{}, error) {
+	entry, ok := files["manifest.json"]
+	if !ok {
+		return nil, fmt.Errorf(T("archive.file_missing"), packagePath, "manifest.json")
+	}
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(entry.Data, &manifest); err != nil {
+		return nil, fmt.Errorf(T("archive.parse_manifest_failed"), packagePath, err)
+	}
+	return manifest, nil
+}
+
+func compareFields(a, b map[string]interface{}) []FieldDifference {
+	diffs := []FieldDifference{}
+	for _, key := range unionKeys(a, b) {
+		if !reflect.DeepEqual(a[key], b[key]) {
+			diffs = append(diffs, FieldDifference{Field: key, A: a[key], B: b[key]})
+		}
+	}
+	return diffs
+}
+
+func compareStringMaps(a, b map[string]string) []FieldDifference {
+	var diffs []FieldDifference
+	for _, key := range unionKeys(a, b) {
+		av, inA := a[key]
+		bv, inB := b[key]
+		if inA && inB && av == bv {
+			continue
+		}
+		diff := FieldDifference{Field: key}
+		if inA {
+			diff.A = av
+		}
+		if inB {
+			diff.B = bv
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// unionKeys 返回两个 map 所有键的有序并集
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fileModeString(header *tar.Header) string {
+	return fmt.Sprintf("%04o", os.FileMode(header.Mode).Perm())
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.2f MB", float64(size)/1024/1024)
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -18,6 +25,8 @@ type ProjectConfig struct {
 	Description string `json:"description"`
 	Version     string `json:"version"`
 	Author      string `json:"author"`
+	Module      string `json:"module"`     // Go 模块路径，默认为项目名称
+	GoVersion   string `json:"go_version"` // go.mod 中的 go 版本，默认为当前工具链版本
 }
 
 type ManifestData struct {
@@ -38,8 +47,25 @@ var (
 	version        string
 	author         string
 	nonInteractive bool
+	modulePath     string
+	goVersion      string
+	runTidy        bool
+	forceCreate    bool
+	mergeCreate    bool
+	dryRunCreate   bool
+	initGit        bool
+	gitAuthor      string
 )
 
+// scaffoldEntry 记录 dry-run 模式下将要生成的文件或目录
+type scaffoldEntry struct {
+	Path   string
+	IsDir  bool
+	Action string
+}
+
+var scaffoldPlan []scaffoldEntry
+
 // createCmd 代表 create 命令
 var createCmd = &cobra.Command{
 	Use:   "create [project-name]",
@@ -52,60 +78,145 @@ var createCmd = &cobra.Command{
 		if len(args) > 0 {
 			projectName = args[0]
 		}
+		runCreate(projectName, false)
+	},
+}
 
-		var config *ProjectConfig
-		var err error
+func init() {
+	rootCmd.AddCommand(createCmd)
+	addCreateFlags(createCmd)
+}
 
-		if nonInteractive {
-			config = &ProjectConfig{
-				Name:        projectName,
-				Description: description,
-				Version:     version,
-				Author:      author,
-			}
-			// 如果未提供则设置默认值
-			if config.Name == "" {
-				fmt.Println("错误: 非交互模式下需要项目名称")
-				return
-			}
-			if config.Description == "" {
-				config.Description = "A dsserv module"
-			}
-			if config.Version == "" {
-				config.Version = "1.0.0"
-			}
-			if config.Author == "" {
-				config.Author = "DataShell Team"
-			}
-		} else {
-			config, err = promptForProjectInfo(projectName)
-			if err != nil {
-				fmt.Printf("获取项目信息时出错: %v\n", err)
-				return
-			}
+// addCreateFlags 添加 create 和 init 共用的标志
+func addCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&description, "description", "d", "", "项目描述")
+	cmd.Flags().StringVarP(&version, "version", "v", "", "项目版本")
+	// -v 在 create 和 init 中表示 --version，同名的本地 --verbose 遮蔽全局标志以释放 -v 简写
+	cmd.Flags().CountVar(&verbosity, "verbose", "输出详细信息 (create 和 init 中没有 -v 简写)")
+	cmd.Flags().StringVarP(&author, "author", "a", "", "项目作者")
+	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "以非交互模式运行")
+	cmd.Flags().StringVar(&modulePath, "module", "", "Go 模块路径 (如 github.com/org/name)，默认为项目名称")
+	cmd.Flags().StringVar(&goVersion, "go-version", "", "go.mod 中的 Go 版本，默认为当前 Go 工具链版本")
+	cmd.Flags().BoolVar(&runTidy, "tidy", false, "创建后执行 go mod tidy 下载依赖并生成 go.sum")
+	cmd.Flags().BoolVar(&forceCreate, "force", false, "目标目录不为空时覆盖已存在的文件")
+	cmd.Flags().BoolVar(&mergeCreate, "merge", false, "目标目录不为空时只写入缺失的文件")
+	cmd.Flags().BoolVar(&dryRunCreate, "dry-run", false, "只打印将要生成的文件树，不写入任何文件")
+	cmd.Flags().BoolVar(&initGit, "git", false, "初始化 git 仓库并创建初始提交，作者默认取自 git config")
+	cmd.Flags().StringVar(&gitAuthor, "git-author", "", "初始提交的作者 (格式: \"Name <email>\")")
+}
+
+// runCreate 执行 create 和 init 的公共流程。
+// inPlace 为 true 时在当前目录中生成项目，项目名称默认为当前目录名。
+func runCreate(projectName string, inPlace bool) {
+	if forceCreate && mergeCreate {
+		printError(T("create.force_merge"))
+		return
+	}
+
+	if projectName != "" {
+		if err := validateName(T("name.kind.project"), projectName); err != nil {
+			printError(T("common.error", err))
+			return
 		}
+	}
 
-		if err := createProject(config); err != nil {
-			fmt.Printf("创建项目时出错: %v\n", err)
+	var config *ProjectConfig
+	var err error
+
+	if nonInteractive {
+		config = &ProjectConfig{
+			Name:        projectName,
+			Description: description,
+			Version:     version,
+			Author:      author,
+		}
+		// 如果未提供则设置默认值
+		if config.Name == "" {
+			printError(T("create.name_required"))
 			return
 		}
+		if config.Description == "" {
+			config.Description = T("create.default_description")
+		}
+		if config.Version == "" {
+			config.Version = "1.0.0"
+		}
+		if config.Author == "" && initGit {
+			config.Author = gitUserAuthor()
+		}
+		if config.Author == "" {
+			config.Author = "DataShell Team"
+		}
+	} else {
+		config, err = promptForProjectInfo(projectName)
+		if err != nil {
+			printError(T("create.prompt_failed", err))
+			return
+		}
+	}
 
-		fmt.Printf("\n✅ 项目 '%s' 创建成功!\n", config.Name)
-		fmt.Printf("\n下一步操作:\n")
-		fmt.Printf("  cd %s\n", config.Name)
-		fmt.Printf("  go mod tidy\n")
-		fmt.Printf("  dscli build\n")
-	},
-}
+	if err := validateName(T("name.kind.project"), config.Name); err != nil {
+		printError(T("common.error", err))
+		return
+	}
 
-func init() {
-	rootCmd.AddCommand(createCmd)
+	config.Module = modulePath
+	if config.Module == "" {
+		config.Module = config.Name
+	}
+	if err := validateModulePath(config.Module); err != nil {
+		printError(T("common.error", err))
+		return
+	}
+	if err := validateGoVersion(goVersion); err != nil {
+		printError(T("common.error", err))
+		return
+	}
+	config.GoVersion = goVersion
+	if config.GoVersion == "" {
+		config.GoVersion = detectGoVersion()
+	}
+
+	projectDir := config.Name
+	if inPlace {
+		projectDir = "."
+	}
+
+	if err := createProject(config, projectDir); err != nil {
+		printError(T("create.failed", err))
+		return
+	}
+
+	if dryRunCreate {
+		printScaffoldPlan(projectDir)
+		return
+	}
 
-	// 添加标志
-	createCmd.Flags().StringVarP(&description, "description", "d", "", "项目描述")
-	createCmd.Flags().StringVarP(&version, "version", "v", "", "项目版本")
-	createCmd.Flags().StringVarP(&author, "author", "a", "", "项目作者")
-	createCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "以非交互模式运行")
+	tidied := false
+	if runTidy {
+		if err := runGoModTidy(projectDir); err != nil {
+			logWarn(T("create.tidy_failed", err))
+		} else {
+			tidied = true
+		}
+	}
+
+	if initGit {
+		if err := initGitRepo(projectDir, gitAuthor); err != nil {
+			logWarn(T("create.git_failed", err))
+		}
+	}
+
+	emitEvent(Event{Type: EventProjectCreated, Project: config.Name, Version: config.Version, Path: projectDir, Module: config.Module})
+	logInfo("\n" + T("create.done", config.Name))
+	logInfo("\n" + T("common.next_steps"))
+	if !inPlace {
+		logInfo("  cd " + config.Name)
+	}
+	if !tidied {
+		logInfo("  go mod tidy")
+	}
+	logInfo("  dscli build")
 }
 
 func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
@@ -119,15 +230,15 @@ func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
 			{
 				Name: "description",
 				Prompt: &survey.Input{
-					Message: "项目描述:",
-					Default: "一个 dsserv 模块",
+					Message: T("create.prompt.description"),
+					Default: T("create.default_description"),
 				},
 				Validate: survey.Required,
 			},
 			{
 				Name: "version",
 				Prompt: &survey.Input{
-					Message: "版本:",
+					Message: T("create.prompt.version"),
 					Default: "1.0.0",
 				},
 				Validate: survey.Required,
@@ -135,8 +246,8 @@ func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
 			{
 				Name: "author",
 				Prompt: &survey.Input{
-					Message: "作者:",
-					Default: "DataShell Team",
+					Message: T("create.prompt.author"),
+					Default: defaultAuthor(),
 				},
 			},
 		}
@@ -148,23 +259,23 @@ func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
 			{
 				Name: "name",
 				Prompt: &survey.Input{
-					Message: "项目名称:",
+					Message: T("create.prompt.name"),
 					Default: initialName,
 				},
-				Validate: survey.Required,
+				Validate: surveyNameValidator(T("name.kind.project")),
 			},
 			{
 				Name: "description",
 				Prompt: &survey.Input{
-					Message: "项目描述:",
-					Default: "一个 dsserv 模块",
+					Message: T("create.prompt.description"),
+					Default: T("create.default_description"),
 				},
 				Validate: survey.Required,
 			},
 			{
 				Name: "version",
 				Prompt: &survey.Input{
-					Message: "版本:",
+					Message: T("create.prompt.version"),
 					Default: "1.0.0",
 				},
 				Validate: survey.Required,
@@ -172,8 +283,8 @@ func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
 			{
 				Name: "author",
 				Prompt: &survey.Input{
-					Message: "作者:",
-					Default: "DataShell Team",
+					Message: T("create.prompt.author"),
+					Default: defaultAuthor(),
 				},
 			},
 		}
@@ -182,11 +293,29 @@ func promptForProjectInfo(initialName string) (*ProjectConfig, error) {
 	}
 }
 
-func createProject(config *ProjectConfig) error {
+// defaultAuthor 返回交互式提示中作者的默认值，使用 --git 时取自 git config
+func defaultAuthor() string {
+	if initGit {
+		if author := gitUserAuthor(); author != "" {
+			return author
+		}
+	}
+	return "DataShell Team"
+}
+
+func createProject(config *ProjectConfig, projectDir string) error {
+	// 目标目录不为空时默认拒绝，避免覆盖已有项目；--dry-run 同样拒绝，与实际运行的结果一致
+	empty, err := isDirEmpty(projectDir)
+	if err != nil {
+		return fmt.Errorf(T("create.check_dir_failed"), err)
+	}
+	if !empty && !forceCreate && !mergeCreate {
+		return fmt.Errorf(T("create.dir_not_empty"), projectDir)
+	}
+
 	// 创建项目目录
-	projectDir := config.Name
-	if err := os.MkdirAll(projectDir, 0755); err != nil {
-		return fmt.Errorf("创建项目目录失败: %w", err)
+	if err := makeDir(projectDir); err != nil {
+		return fmt.Errorf(T("create.mkdir_failed"), err)
 	}
 
 	// 创建子目录
@@ -199,8 +328,8 @@ func createProject(config *ProjectConfig) error {
 	}
 
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("创建目录 %s 失败: %w", dir, err)
+		if err := makeDir(dir); err != nil {
+			return fmt.Errorf(T("common.create_dir_failed"), dir, err)
 		}
 	}
 
@@ -238,25 +367,91 @@ func createProject(config *ProjectConfig) error {
 func createGoMod(projectDir string, config *ProjectConfig) error {
 	content := fmt.Sprintf(`module %s
 
-go 1.21
+go %s
 
 require (
 	github.com/spf13/cobra v1.8.0
 	github.com/spf13/viper v1.18.2
 )
-`, config.Name)
+`, config.Module, config.GoVersion)
 
 	return writeFile(filepath.Join(projectDir, "go.mod"), content)
 }
 
+// validateModulePath 对 Go 模块路径做基本校验
+func validateModulePath(path string) error {
+	if path == "" {
+		return errors.New(T("create.module_empty"))
+	}
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf(T("create.module_slash"), path)
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return fmt.Errorf(T("create.module_relative"), path)
+		}
+		for _, r := range elem {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-._~", r)) {
+				return fmt.Errorf(T("create.module_char"), path, r)
+			}
+		}
+	}
+	return nil
+}
+
+// goModVersionPattern go.mod 中 go 指令接受的版本格式，如 1.21、1.22.3
+var goModVersionPattern = regexp.MustCompile(`^1\.\d+(\.\d+)?$`)
+
+// validateGoVersion 校验 --go-version 的格式，空值表示使用当前工具链版本
+func validateGoVersion(version string) error {
+	if version != "" && !goModVersionPattern.MatchString(version) {
+		return fmt.Errorf(T("create.go_version_invalid"), version)
+	}
+	return nil
+}
+
+// detectGoVersion 返回当前 Go 工具链的 major.minor 版本，无法检测时使用 dscli 自身的编译版本
+func detectGoVersion() string {
+	goVer := runtime.Version()
+	if out, err := exec.Command("go", "env", "GOVERSION").Output(); err == nil {
+		goVer = strings.TrimSpace(string(out))
+	}
+
+	goVer = strings.TrimPrefix(goVer, "go")
+	// 去掉 "1.22.3 X:..." 之类的附加信息和补丁版本号
+	goVer = strings.Fields(goVer + " ")[0]
+	parts := strings.Split(goVer, ".")
+	if len(parts) < 2 {
+		return "1.21"
+	}
+	minor := parts[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i] // 去掉 rc1、beta1 等后缀
+	}
+	if minor == "" {
+		return "1.21"
+	}
+	return parts[0] + "." + minor
+}
+
+// runGoModTidy 在项目目录中执行 go mod tidy，使用当前环境的 GOPROXY、GOFLAGS 等设置
+func runGoModTidy(projectDir string) error {
+	logInfo(T("create.tidying"))
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = projectDir
+	cmd.Env = os.Environ()
+	cmd.Stdout, cmd.Stderr = commandOutput()
+	return cmd.Run()
+}
+
 // 原createMainGo函数已被createMainGoInCmd替代，此函数已删除
 
 // createMainGoInCmd 在cmd目录下创建主程序的main.go
 func createMainGoInCmd(projectDir string, config *ProjectConfig) error {
 	// 创建cmd/项目名称目录
 	cmdDir := filepath.Join(projectDir, "cmd", config.Name)
-	if err := os.MkdirAll(cmdDir, 0755); err != nil {
-		return fmt.Errorf("创建cmd目录失败: %w", err)
+	if err := makeDir(cmdDir); err != nil {
+		return fmt.Errorf(T("add.create_cmd_dir_failed"), err)
 	}
 
 	tmpl := `package main
@@ -297,50 +492,39 @@ func main() {
 }
 
 func run() {
-	// 加载配置
+	// {{T "scaffold.main.load_config"}}
 	if configFile != "" {
 		viper.SetConfigFile(configFile)
 		if err := viper.ReadInConfig(); err != nil {
-			log.Printf("警告: 无法读取配置文件: %v", err)
+			log.Printf("{{T "scaffold.main.config_warning"}}", err)
 		}
 	}
 
-	fmt.Printf("启动 {{.Name}} v%s (构建时间: %s)\n", version, buildDate)
+	fmt.Printf("{{T "scaffold.main.starting" .Name}}\n", version, buildDate)
 	fmt.Println("{{.Description}}")
 
-	// 设置优雅关闭
+	// {{T "scaffold.main.setup_shutdown"}}
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	// 主服务循环
+	// {{T "scaffold.main.loop"}}
 	go func() {
 		for {
-			// 在这里编写您的主要服务逻辑
-			fmt.Println("服务正在运行...")
+			// {{T "scaffold.main.logic"}}
+			fmt.Println("{{T "scaffold.main.running"}}")
 			time.Sleep(10 * time.Second)
 		}
 	}()
 
-	// 等待关闭信号
+	// {{T "scaffold.main.wait_signal"}}
 	<-c
-	fmt.Println("\n正在优雅关闭...")
-	// 清理逻辑在这里
-	fmt.Println("服务已停止。")
+	fmt.Println("\n{{T "scaffold.main.shutting_down"}}")
+	// {{T "scaffold.main.cleanup"}}
+	fmt.Println("{{T "scaffold.main.stopped"}}")
 }
 `
 
-	t, err := template.New("main").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
-	file, err := os.Create(filepath.Join(cmdDir, "main.go"))
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return t.Execute(file, config)
+	return writeTemplate(filepath.Join(cmdDir, "main.go"), "main", tmpl, config)
 }
 
 func createManifest(projectDir string, config *ProjectConfig) error {
@@ -385,10 +569,10 @@ func createReadme(projectDir string, config *ProjectConfig) error {
 
 ` + "```bash\n" +
 		`# Run the application
-go run main.go
+go run ./cmd/{{.Name}}
 
 # Or build and run
-go build -o bin/{{.Name}}
+go build -o bin/{{.Name}} ./cmd/{{.Name}}
 ./bin/{{.Name}}
 ` + "```\n\n" +
 		`## Build
@@ -409,18 +593,7 @@ Use dscli to build the module:
 {{.Version}}
 `
 
-	t, err := template.New("readme").Parse(tmpl)
-	if err != nil {
-		return err
-	}
-
-	file, err := os.Create(filepath.Join(projectDir, "README.md"))
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return t.Execute(file, config)
+	return writeTemplate(filepath.Join(projectDir, "README.md"), "readme", tmpl, config)
 }
 
 func createGitignore(projectDir string) error {
@@ -489,6 +662,106 @@ func createDscliConfig(projectDir string) error {
 	return writeFile(filepath.Join(projectDir, ".dscli.json"), string(data))
 }
 
+// writeTemplate 渲染模板并写入文件
+func writeTemplate(path, name, tmpl string, data interface{}) error {
+	t, err := template.New(name).Funcs(template.FuncMap{"T": T}).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	return writeFile(path, buf.String())
+}
+
+// writeFile 写入脚手架文件。
+// --merge 模式下跳过已存在的文件，--dry-run 模式下只记录将要执行的操作。
 func writeFile(path, content string) error {
-	return os.WriteFile(path, []byte(content), 0644)
+	_, err := os.Stat(path)
+	exists := err == nil
+
+	if dryRunCreate {
+		action := T("create.plan.create")
+		if exists && mergeCreate {
+			action = T("create.plan.skip")
+		} else if exists {
+			action = T("create.plan.overwrite")
+		}
+		scaffoldPlan = append(scaffoldPlan, scaffoldEntry{Path: path, Action: action})
+		return nil
+	}
+
+	if exists && mergeCreate {
+		logInfo(T("create.skip_existing", path))
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+	emitEvent(Event{Type: EventFileWritten, Path: path, Size: int64(len(content))})
+	return nil
+}
+
+// makeDir 创建脚手架目录，--dry-run 模式下只记录
+func makeDir(dir string) error {
+	if dryRunCreate {
+		scaffoldPlan = append(scaffoldPlan, scaffoldEntry{Path: dir, IsDir: true})
+		return nil
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// isDirEmpty 判断目录是否为空，不存在的目录视为空，忽略 .git 目录
+func isDirEmpty(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Name() != ".git" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// printScaffoldPlan 以树形结构打印 dry-run 模式下将要生成的文件
+func printScaffoldPlan(projectDir string) {
+	seen := make(map[string]bool)
+	var entries []scaffoldEntry
+	for _, entry := range scaffoldPlan {
+		rel, err := filepath.Rel(projectDir, entry.Path)
+		if err != nil || rel == "." || seen[rel] {
+			continue
+		}
+		seen[rel] = true
+		entry.Path = filepath.ToSlash(rel)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	fmt.Println(T("create.plan_header", projectDir))
+	rootName := projectDir
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		rootName = filepath.Base(abs)
+	}
+	fmt.Printf("%s/\n", rootName)
+	for _, entry := range entries {
+		depth := strings.Count(entry.Path, "/")
+		name := path.Base(entry.Path)
+		if entry.IsDir {
+			fmt.Printf("%s%s/\n", strings.Repeat("  ", depth+1), name)
+		} else {
+			fmt.Printf("%s%s  [%s]\n", strings.Repeat("  ", depth+1), name, entry.Action)
+		}
+	}
 }
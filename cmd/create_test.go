@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// resetCreateFlags 测试结束后恢复 create 的标志
+func resetCreateFlags(t *testing.T) {
+	t.Helper()
+	saved := []bool{nonInteractive, forceCreate, mergeCreate, dryRunCreate, initGit}
+	savedGoVersion := goVersion
+	t.Cleanup(func() {
+		nonInteractive, forceCreate, mergeCreate, dryRunCreate, initGit = saved[0], saved[1], saved[2], saved[3], saved[4]
+		goVersion = savedGoVersion
+		scaffoldPlan = nil
+	})
+	nonInteractive, forceCreate, mergeCreate, dryRunCreate, initGit = true, false, false, false, false
+	goVersion = "1.21"
+	scaffoldPlan = nil
+}
+
+func TestCreateDryRunRefusesNonEmptyDir(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	resetCreateFlags(t)
+	resetExitCode(t)
+	writeFiles(t, dir, map[string]string{"demo/main.go": "package main // existing\n"})
+
+	dryRunCreate = true
+	runCreate("demo", false)
+	if exitCode == 0 {
+		t.Error("dry-run into a non-empty directory should fail like the real run")
+	}
+	if len(scaffoldPlan) != 0 {
+		t.Errorf("dry-run should not plan any files after refusing, got %d entries", len(scaffoldPlan))
+	}
+
+	dryRunCreate = false
+	exitCode = 0
+	runCreate("demo", false)
+	if exitCode == 0 {
+		t.Error("create into a non-empty directory should fail")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "demo", "main.go")); string(data) != "package main // existing\n" {
+		t.Error("existing file was modified")
+	}
+}
+
+func TestCreateDryRunWithMerge(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	resetCreateFlags(t)
+	resetExitCode(t)
+	writeFiles(t, dir, map[string]string{"demo/go.mod": "module existing\n"})
+
+	dryRunCreate, mergeCreate = true, true
+	runCreate("demo", false)
+	if exitCode != 0 {
+		t.Fatal("dry-run with --merge should succeed")
+	}
+
+	actions := make(map[string]string)
+	for _, entry := range scaffoldPlan {
+		actions[filepath.ToSlash(entry.Path)] = entry.Action
+	}
+	if actions["demo/go.mod"] != T("create.plan.skip") {
+		t.Errorf("existing go.mod action = %q, want skip", actions["demo/go.mod"])
+	}
+	if actions["demo/manifest.json"] != T("create.plan.create") {
+		t.Errorf("manifest.json action = %q, want create", actions["demo/manifest.json"])
+	}
+	if _, err := os.Stat(filepath.Join(dir, "demo", "manifest.json")); !os.IsNotExist(err) {
+		t.Error("dry-run must not write files")
+	}
+}
+
+func TestCreateVersionShorthand(t *testing.T) {
+	defer func(v string, n int) { version, verbosity = v, n }(version, verbosity)
+
+	for _, cmd := range []*cobra.Command{createCmd, initCmd} {
+		version, verbosity = "", 0
+		if err := cmd.ParseFlags([]string{"-v", "1.2.3", "--verbose"}); err != nil {
+			t.Fatalf("%s: %v", cmd.Name(), err)
+		}
+		if version != "1.2.3" || verbosity != 1 {
+			t.Errorf("%s -v 1.2.3 --verbose: version = %q, verbosity = %d", cmd.Name(), version, verbosity)
+		}
+	}
+}
+
+func TestCreateRejectsInvalidGoVersion(t *testing.T) {
+	for _, v := range []string{"1.21", "1.22.3"} {
+		if err := validateGoVersion(v); err != nil {
+			t.Errorf("validateGoVersion(%q) = %v, want nil", v, err)
+		}
+	}
+
+	for _, v := range []string{"go1.21", "1", "1.21rc1", "1.21.0.1", "2.0", "1.21 "} {
+		dir := t.TempDir()
+		chdir(t, dir)
+		resetCreateFlags(t)
+		resetExitCode(t)
+
+		goVersion = v
+		runCreate("demo", false)
+		if exitCode == 0 {
+			t.Errorf("--go-version %q should be rejected", v)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "demo")); !os.IsNotExist(err) {
+			t.Errorf("--go-version %q: nothing should be written", v)
+		}
+	}
+}
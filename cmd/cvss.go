@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// OSVSeverity OSV 记录 severity 数组中的一项，score 为 CVSS 向量
+type OSVSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// cvssV3Weights CVSS v3.x 基础指标的权重，PR 在作用域改变 (S:C) 时使用 cvssV3ScopeChangedPR
+var cvssV3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+var cvssV3ScopeChangedPR = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+
+// cvssV2Weights CVSS v2 基础指标的权重
+var cvssV2Weights = map[string]map[string]float64{
+	"AV": {"L": 0.395, "A": 0.646, "N": 1.0},
+	"AC": {"H": 0.35, "M": 0.61, "L": 0.71},
+	"Au": {"M": 0.45, "S": 0.56, "N": 0.704},
+	"C":  {"N": 0, "P": 0.275, "C": 0.660},
+	"I":  {"N": 0, "P": 0.275, "C": 0.660},
+	"A":  {"N": 0, "P": 0.275, "C": 0.660},
+}
+
+// osvSeverity 确定漏洞记录的严重级别。
+// 优先使用 database_specific.severity（如 GitHub 公告），否则按 severity 中的 CVSS v3 或 v2 向量计算基础分数，
+// 都没有时返回 unknown。Go 漏洞数据库的记录通常两者都没有。
+func osvSeverity(entry OSVEntry) string {
+	if severity := normalizeSeverity(entry.DatabaseSpecific.Severity); severity != "unknown" {
+		return severity
+	}
+
+	best := "unknown"
+	for _, s := range entry.Severity {
+		var score float64
+		var err error
+		switch s.Type {
+		case "CVSS_V3":
+			score, err = cvssV3Score(s.Score)
+		case "CVSS_V2":
+			score, err = cvssV2Score(s.Score)
+		default:
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if severity := cvssSeverity(score); auditSeverityLevels[severity] > auditSeverityLevels[best] {
+			best = severity
+		}
+	}
+	return best
+}
+
+// cvssSeverity 按 CVSS v3 的定性评级将基础分数转换为严重级别，0 分记为 unknown
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9.0:
+		return "critical"
+	case score >= 7.0:
+		return "high"
+	case score >= 4.0:
+		return "moderate"
+	case score > 0:
+		return "low"
+	}
+	return "unknown"
+}
+
+// parseCVSSVector 将 "AV:N/AC:L/..." 形式的向量解析为指标，检查所有基础指标都存在且取值有效
+func parseCVSSVector(vector string, weights map[string]map[string]float64) (map[string]string, error) {
+	metrics := make(map[string]string)
+	for _, part := range strings.Split(vector, "/") {
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf(T("cvss.invalid"), vector)
+		}
+		metrics[name] = value
+	}
+	for name, values := range weights {
+		if _, ok := values[metrics[name]]; !ok {
+			return nil, fmt.Errorf(T("cvss.missing_metric"), vector, name)
+		}
+	}
+	return metrics, nil
+}
+
+// cvssV3Score 计算 CVSS v3.0/v3.1 向量的基础分数，如 "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+func cvssV3Score(vector string) (float64, error) {
+	body, ok := strings.CutPrefix(vector, "CVSS:3.1/")
+	if !ok {
+		if body, ok = strings.CutPrefix(vector, "CVSS:3.0/"); !ok {
+			return 0, fmt.Errorf(T("cvss.invalid_v3"), vector)
+		}
+	}
+	m, err := parseCVSSVector(body, cvssV3Weights)
+	if err != nil {
+		return 0, err
+	}
+	changed := m["S"] == "C"
+	if !changed && m["S"] != "U" {
+		return 0, fmt.Errorf(T("cvss.missing_scope"), vector)
+	}
+
+	w := func(name string) float64 { return cvssV3Weights[name][m[name]] }
+	pr := w("PR")
+	if changed {
+		pr = cvssV3ScopeChangedPR[m["PR"]]
+	}
+
+	iss := 1 - (1-w("C"))*(1-w("I"))*(1-w("A"))
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+	exploitability := 8.22 * w("AV") * w("AC") * pr * w("UI")
+	if changed {
+		return cvssRoundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return cvssRoundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// cvssRoundUp 按 CVSS v3.1 规范向上取整到一位小数，避免浮点误差
+func cvssRoundUp(value float64) float64 {
+	n := int(math.Round(value * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
+
+// cvssV2Score 计算 CVSS v2 向量的基础分数，如 "AV:N/AC:L/Au:N/C:P/I:P/A:P"
+func cvssV2Score(vector string) (float64, error) {
+	m, err := parseCVSSVector(vector, cvssV2Weights)
+	if err != nil {
+		return 0, err
+	}
+	w := func(name string) float64 { return cvssV2Weights[name][m[name]] }
+
+	impact := 10.41 * (1 - (1-w("C"))*(1-w("I"))*(1-w("A")))
+	if impact == 0 {
+		return 0, nil
+	}
+	exploitability := 20 * w("AV") * w("AC") * w("Au")
+	return math.Round((0.6*impact+0.4*exploitability-1.5)*1.176*10) / 10, nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// 二进制差分格式: 由操作序列组成
+//   'C' uvarint(offset) uvarint(length)  从旧文件复制
+//   'I' uvarint(length) data             插入新数据
+const (
+	deltaOpCopy   = 'C'
+	deltaOpInsert = 'I'
+
+	// deltaBlockSize 用于匹配的块大小
+	deltaBlockSize = 32
+	// deltaMaxCandidates 每个哈希值最多记录的旧文件位置
+	deltaMaxCandidates = 8
+	// deltaHashBase 滚动哈希的基数
+	deltaHashBase = 257
+)
+
+// computeDelta 生成将 oldData 转换为 newData 的二进制差分。
+// 对旧文件按块建立滚动哈希索引，在新文件中逐字节滑动查找匹配并尽量向前扩展。
+func computeDelta(oldData, newData []byte) []byte {
+	var out bytes.Buffer
+	var pending []byte
+
+	flushInsert := func() {
+		if len(pending) == 0 {
+			return
+		}
+		out.WriteByte(deltaOpInsert)
+		writeUvarint(&out, uint64(len(pending)))
+		out.Write(pending)
+		pending = pending[:0]
+	}
+
+	if len(oldData) < deltaBlockSize || len(newData) < deltaBlockSize {
+		pending = append(pending, newData...)
+		flushInsert()
+		return out.Bytes()
+	}
+
+	// 旧文件的块索引
+	index := make(map[uint32][]int)
+	for off := 0; off+deltaBlockSize <= len(oldData); off += deltaBlockSize {
+		h := rollingHash(oldData[off : off+deltaBlockSize])
+		if len(index[h]) < deltaMaxCandidates {
+			index[h] = append(index[h], off)
+		}
+	}
+
+	// deltaHashBase^(deltaBlockSize-1)，用于移出窗口首字节
+	var pow uint32 = 1
+	for i := 0; i < deltaBlockSize-1; i++ {
+		pow *= deltaHashBase
+	}
+
+	pos := 0
+	h := rollingHash(newData[:deltaBlockSize])
+	for pos+deltaBlockSize <= len(newData) {
+		bestOff, bestLen := -1, 0
+		for _, off := range index[h] {
+			if !bytes.Equal(oldData[off:off+deltaBlockSize], newData[pos:pos+deltaBlockSize]) {
+				continue
+			}
+			n := deltaBlockSize
+			for off+n < len(oldData) && pos+n < len(newData) && oldData[off+n] == newData[pos+n] {
+				n++
+			}
+			if n > bestLen {
+				bestOff, bestLen = off, n
+			}
+		}
+
+		if bestLen > 0 {
+			flushInsert()
+			out.WriteByte(deltaOpCopy)
+			writeUvarint(&out, uint64(bestOff))
+			writeUvarint(&out, uint64(bestLen))
+			pos += bestLen
+			if pos+deltaBlockSize <= len(newData) {
+				h = rollingHash(newData[pos : pos+deltaBlockSize])
+			}
+			continue
+		}
+
+		pending = append(pending, newData[pos])
+		if pos+deltaBlockSize < len(newData) {
+			h = (h-uint32(newData[pos])*pow)*deltaHashBase + uint32(newData[pos+deltaBlockSize])
+		}
+		pos++
+	}
+
+	pending = append(pending, newData[pos:]...)
+	flushInsert()
+	return out.Bytes()
+}
+
+// applyDelta 将二进制差分应用到 oldData，重建新文件
+func applyDelta(oldData, delta []byte) ([]byte, error) {
+	var out bytes.Buffer
+	r := bytes.NewReader(delta)
+	for r.Len() > 0 {
+		op, _ := r.ReadByte()
+		switch op {
+		case deltaOpCopy:
+			off, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, fmt.Errorf(T("delta.corrupt"), err)
+			}
+			n, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, fmt.Errorf(T("delta.corrupt"), err)
+			}
+			if off+n > uint64(len(oldData)) || off+n < off {
+				return nil, errors.New(T("delta.copy_out_of_range"))
+			}
+			out.Write(oldData[off : off+n])
+		case deltaOpInsert:
+			n, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, fmt.Errorf(T("delta.corrupt"), err)
+			}
+			if n > uint64(r.Len()) {
+				return nil, errors.New(T("delta.insert_out_of_range"))
+			}
+			data := make([]byte, n)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, err
+			}
+			out.Write(data)
+		default:
+			return nil, fmt.Errorf(T("delta.unknown_op"), op)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func rollingHash(data []byte) uint32 {
+	var h uint32
+	for _, b := range data {
+		h = h*deltaHashBase + uint32(b)
+	}
+	return h
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// 检查结果的状态
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// DoctorCheck 一项环境检查的结果
+type DoctorCheck struct {
+	Section string
+	Name    string
+	Status  string
+	Message string
+	Fix     string // 修复建议，检查通过时为空
+}
+
+// doctorCmd 代表 doctor 命令
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "检查构建环境并给出修复建议",
+	Long: `检查 Go 工具链、项目结构、配置文件、写入权限和目标平台支持情况，
+对发现的问题给出修复建议。存在失败的检查项时以非零状态码退出。
+
+在项目目录外运行时只检查工具链。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checks := runDoctorChecks()
+		printDoctorChecks(checks)
+		for _, check := range checks {
+			if check.Status == doctorFail {
+				setExitCode(1)
+				return
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctor 收集检查结果
+type doctor struct {
+	section string
+	checks  []DoctorCheck
+}
+
+func (d *doctor) add(status, name, message, fix string) {
+	d.checks = append(d.checks, DoctorCheck{Section: d.section, Name: name, Status: status, Message: message, Fix: fix})
+}
+
+func (d *doctor) ok(name, message string)        { d.add(doctorOK, name, message, "") }
+func (d *doctor) warn(name, message, fix string) { d.add(doctorWarn, name, message, fix) }
+func (d *doctor) fail(name, message, fix string) { d.add(doctorFail, name, message, fix) }
+
+// runDoctorChecks 依次运行所有检查，go 命令不可用时跳过依赖工具链的检查
+func runDoctorChecks() []DoctorCheck {
+	d := &doctor{}
+	project := isValidProject()
+
+	d.section = T("doctor.section.toolchain")
+	if checkGoToolchain(d, project) {
+		checkGoEnv(d)
+	}
+
+	if project {
+		d.section = T("doctor.section.project")
+		checkProject(d)
+
+		d.section = T("doctor.section.permissions")
+		checkPermissions(d)
+	} else {
+		d.section = T("doctor.section.project")
+		d.warn(T("doctor.project"), T("doctor.not_project"), T("doctor.fix.not_project"))
+	}
+
+	if _, err := exec.LookPath("go"); err == nil {
+		d.section = T("doctor.section.targets")
+		checkTargets(d)
+	}
+	return d.checks
+}
+
+// checkGoToolchain 检查 go 命令和 Go 版本，go 命令不可用时返回 false
+func checkGoToolchain(d *doctor, project bool) bool {
+	path, err := exec.LookPath("go")
+	if err != nil {
+		d.fail(T("doctor.go"), T("doctor.go_missing"), T("doctor.fix.go_missing"))
+		return false
+	}
+
+	version, err := goEnv("GOVERSION")
+	if err != nil || version == "" {
+		d.fail(T("doctor.go"), T("doctor.go_broken", path, err), T("doctor.fix.go_broken"))
+		return false
+	}
+	version = strings.Fields(version)[0]
+	d.ok(T("doctor.go"), fmt.Sprintf("%s (%s)", path, version))
+
+	if !project {
+		return true
+	}
+	required, toolchain, err := goModDirectives("go.mod")
+	if err != nil {
+		// go.mod 缺失在项目检查中报告
+		return true
+	}
+	if toolchain != "" && compareGoVersions(toolchain, required) > 0 {
+		required = toolchain
+	}
+	if required == "" || compareGoVersions(version, required) >= 0 {
+		d.ok(T("doctor.go_version"), T("doctor.go_version_ok", version, required))
+		return true
+	}
+
+	mode, _ := goEnv("GOTOOLCHAIN")
+	if mode == "local" {
+		d.fail(T("doctor.go_version"), T("doctor.go_version_old", version, required), T("doctor.fix.go_version_local", required))
+	} else {
+		d.warn(T("doctor.go_version"), T("doctor.go_version_download", version, required), T("doctor.fix.go_version_download", required))
+	}
+	return true
+}
+
+// checkGoEnv 检查 GOFLAGS、cgo 设置以及模块缓存和构建缓存是否可写
+func checkGoEnv(d *doctor) {
+	goflags, _ := goEnv("GOFLAGS")
+	switch {
+	case goflags == "":
+		d.ok("GOFLAGS", T("doctor.goflags_empty"))
+	case strings.Contains(goflags, "-mod=vendor") && !dirExists("vendor"):
+		d.fail("GOFLAGS", T("doctor.goflags_vendor", goflags), T("doctor.fix.goflags_vendor"))
+	case strings.Contains(goflags, "-race"):
+		d.warn("GOFLAGS", T("doctor.goflags_race", goflags), T("doctor.fix.goflags"))
+	case strings.Contains(goflags, "-ldflags"):
+		d.warn("GOFLAGS", T("doctor.goflags_ldflags", goflags), T("doctor.fix.goflags"))
+	case strings.Contains(goflags, "-buildmode"):
+		d.warn("GOFLAGS", T("doctor.goflags_set", goflags), T("doctor.fix.goflags"))
+	default:
+		d.ok("GOFLAGS", goflags)
+	}
+
+	cgo, _ := goEnv("CGO_ENABLED")
+	cc, _ := goEnv("CC")
+	raceTests := false
+	if buildConfig == nil {
+		loadBuildConfig()
+	}
+	if buildConfig != nil {
+		if stages, err := parseStages(buildConfig.Stages); err == nil {
+			for _, stage := range stages {
+				raceTests = raceTests || (stage.Name == "test" && stage.Race)
+			}
+		}
+	}
+	switch {
+	case cgo != "1" && raceTests:
+		d.warn("CGO_ENABLED", T("doctor.cgo_disabled_race"), T("doctor.fix.cgo_disabled_race"))
+	case cgo == "1" && cc != "" && !commandExists(cc):
+		d.warn("CGO_ENABLED", T("doctor.cgo_no_cc", cc), T("doctor.fix.cgo_no_cc"))
+	default:
+		d.ok("CGO_ENABLED", T("doctor.cgo_ok", cgo))
+	}
+
+	for _, name := range []string{"GOMODCACHE", "GOCACHE"} {
+		dir, err := goEnv(name)
+		if err != nil || dir == "" || dir == "off" {
+			d.warn(name, T("doctor.cache_unset"), T("doctor.fix.cache_unwritable", name))
+			continue
+		}
+		if err := checkWritable(dir); err != nil {
+			d.fail(name, T("doctor.not_writable", dir, err), T("doctor.fix.cache_unwritable", name))
+			continue
+		}
+		d.ok(name, dir)
+	}
+}
+
+// checkProject 检查 manifest.json、go.mod、.dscli.json 和可执行文件布局
+func checkProject(d *doctor) {
+	projectName := ""
+	manifest, err := readManifest()
+	if err != nil {
+		d.fail("manifest.json", T("doctor.manifest_invalid", err), T("doctor.fix.manifest"))
+	} else {
+		projectName, _ = manifest["name"].(string)
+		version, _ := manifest["version"].(string)
+		switch {
+		case projectName == "":
+			d.fail("manifest.json", T("doctor.manifest_missing_field", "name"), T("doctor.fix.manifest"))
+		case validateName(T("name.kind.project"), projectName) != nil:
+			d.fail("manifest.json", validateName(T("name.kind.project"), projectName).Error(), T("doctor.fix.manifest"))
+		case version == "":
+			d.fail("manifest.json", T("doctor.manifest_missing_field", "version"), T("doctor.fix.manifest"))
+		default:
+			d.ok("manifest.json", fmt.Sprintf("%s %s", projectName, version))
+		}
+	}
+
+	if _, err := os.Stat("go.mod"); err != nil {
+		d.fail("go.mod", T("doctor.gomod_missing"), T("doctor.fix.gomod_missing"))
+	} else {
+		d.ok("go.mod", T("doctor.exists"))
+	}
+
+	if err := loadBuildConfig(); err != nil {
+		d.fail(".dscli.json", err.Error(), T("doctor.fix.config"))
+		return
+	}
+	if problems := configProblems(); len(problems) > 0 {
+		for _, problem := range problems {
+			d.fail(".dscli.json", problem, T("doctor.fix.config"))
+		}
+	} else if _, err := os.Stat(".dscli.json"); err != nil {
+		d.ok(".dscli.json", T("doctor.config_default"))
+	} else {
+		d.ok(".dscli.json", T("doctor.config_ok"))
+	}
+
+	if assets, err := parseAssets(buildConfig.Assets); err == nil {
+		for _, asset := range assets {
+			if _, err := os.Stat(asset.Source); err != nil {
+				d.warn("assets", T("doctor.asset_missing", asset.Source), T("doctor.fix.asset_missing"))
+			}
+		}
+	}
+
+	if projectName == "" {
+		return
+	}
+	executables, err := discoverExecutables(projectName)
+	switch {
+	case err != nil:
+		d.fail(T("doctor.executables"), err.Error(), T("doctor.fix.executables"))
+	case len(executables) == 0:
+		d.fail(T("doctor.executables"), T("build.no_executables"), T("doctor.fix.executables"))
+	default:
+		var names []string
+		for _, exe := range executables {
+			names = append(names, fmt.Sprintf("%s (%s)", exe.Name, exe.Source))
+		}
+		d.ok(T("doctor.executables"), strings.Join(names, ", "))
+	}
+}
+
+// configProblems 检查 .dscli.json 中需要在构建时才解析的字段
+func configProblems() []string {
+	var problems []string
+	if _, err := parseAssets(buildConfig.Assets); err != nil {
+		problems = append(problems, "assets: "+err.Error())
+	}
+	if err := validateHooks(buildConfig.Hooks); err != nil {
+		problems = append(problems, "hooks: "+err.Error())
+	}
+	if _, err := parseStages(buildConfig.Stages); err != nil {
+		problems = append(problems, "stages: "+err.Error())
+	}
+	var profiles []string
+	for name := range buildConfig.Profiles {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	for _, name := range profiles {
+		if _, err := parseStages(buildConfig.Profiles[name].Stages); err != nil {
+			problems = append(problems, fmt.Sprintf("profiles.%s.stages: %v", name, err))
+		}
+	}
+	if failOn := buildConfig.Audit.FailOn; failOn != "" && failOn != "any" && normalizeSeverity(failOn) == "unknown" {
+		problems = append(problems, "audit.fail_on: "+failOn)
+	}
+	return problems
+}
+
+// checkPermissions 检查项目目录、输出目录和 bin 暂存目录是否可写
+func checkPermissions(d *doctor) {
+	for _, dir := range []string{".", outputDir(), "bin"} {
+		if err := checkWritable(dir); err != nil {
+			d.fail(dir, T("doctor.not_writable", dir, err), T("doctor.fix.dir_unwritable", dir))
+			continue
+		}
+		d.ok(dir, T("doctor.writable"))
+	}
+	if err := checkCleanDir(outputDir()); err != nil {
+		d.warn(outputDir(), T("doctor.output_dir_unsafe", err), T("doctor.fix.output_dir_unsafe"))
+	}
+}
+
+// checkTargets 检查当前平台和 -t all 的目标是否受已安装的工具链支持
+func checkTargets(d *doctor) {
+	supported, err := goDistList()
+	if err != nil {
+		d.fail("go tool dist list", err.Error(), T("doctor.fix.go_broken"))
+		return
+	}
+	d.ok("go tool dist list", T("doctor.targets_count", len(supported)))
+
+	host := BuildTarget{runtime.GOOS, runtime.GOARCH}
+	if !containsTarget(supported, host) {
+		d.fail(T("doctor.host_target"), T("doctor.target_unsupported", host.OS+"/"+host.Arch), T("doctor.fix.targets"))
+	}
+
+	var missing []string
+	for _, target := range buildTargets {
+		if !containsTarget(supported, target) {
+			missing = append(missing, target.OS+"/"+target.Arch)
+		}
+	}
+	if len(missing) > 0 {
+		d.warn("-t all", T("doctor.target_unsupported", strings.Join(missing, ", ")), T("doctor.fix.targets"))
+	} else {
+		d.ok("-t all", T("doctor.targets_all_ok", len(buildTargets)))
+	}
+}
+
+// printDoctorChecks 按分组输出检查结果和修复建议
+func printDoctorChecks(checks []DoctorCheck) {
+	section := ""
+	counts := map[string]int{}
+	for _, check := range checks {
+		if check.Section != section {
+			section = check.Section
+			logInfo("\n" + section)
+		}
+		counts[check.Status]++
+
+		// 修复建议与检查结果使用相同的级别，-q 时仍然显示
+		level, icon := LogInfo, "✅ "
+		switch check.Status {
+		case doctorWarn:
+			level, icon = LogWarn, "⚠️  "
+		case doctorFail:
+			level, icon = LogError, "❌ "
+		}
+		logMessage(level, fmt.Sprintf("  %s%s: %s", icon, check.Name, check.Message))
+		if check.Fix != "" {
+			logMessage(level, "     "+T("doctor.fix", check.Fix))
+		}
+	}
+	logInfo("\n" + T("doctor.summary", counts[doctorOK], counts[doctorWarn], counts[doctorFail]))
+}
+
+// checkWritable 检查目录是否可写，目录不存在时检查最近的已存在的上级目录
+func checkWritable(dir string) error {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf(T("doctor.not_dir"), dir)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	file, err := os.CreateTemp(dir, ".dscli-doctor-*")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
+
+// containsTarget 判断目标列表中是否包含指定目标
+func containsTarget(targets []BuildTarget, target BuildTarget) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// dirExists 判断目录是否存在
+func dirExists(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// commandExists 判断命令是否可以执行，cmd 可以包含参数，如 "gcc -m64"
+func commandExists(cmd string) bool {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := exec.LookPath(fields[0])
+	return err == nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// 事件类型，字段名和取值保持稳定，供 CI 等工具解析
+const (
+	EventBuildStarted     = "build.started"
+	EventStageFinished    = "stage.finished"
+	EventTargetStarted    = "target.started"
+	EventTargetFailed     = "target.failed"
+	EventExecutableBuilt  = "executable.built"
+	EventExecutableFailed = "executable.failed"
+	EventAssetSkipped     = "asset.skipped"
+	EventPackageWritten   = "package.written"
+	EventChecksumsWritten = "checksums.written"
+	EventBuildFinished    = "build.finished"
+	EventProjectCreated   = "project.created"
+	EventFileWritten      = "file.written"
+	EventExecutableAdded  = "executable.added"
+	EventAuditReport      = "audit.report"
+	EventCompareResult    = "compare.result"
+	EventError            = "error"
+)
+
+// Event 结构化输出中的一个事件，未使用的字段省略
+type Event struct {
+	Type       string   `json:"type"`
+	Time       string   `json:"time"`
+	Command    string   `json:"command"`
+	Project    string   `json:"project,omitempty"`
+	Version    string   `json:"version,omitempty"`
+	Module     string   `json:"module,omitempty"`
+	Targets    []string `json:"targets,omitempty"`
+	Target     string   `json:"target,omitempty"`
+	Executable string   `json:"executable,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	Stage      string   `json:"stage,omitempty"`
+	Path       string   `json:"path,omitempty"`
+	Size       int64    `json:"size,omitempty"`
+	SHA256     string   `json:"sha256,omitempty"`
+	Cached     bool     `json:"cached,omitempty"`
+	DurationMS int64    `json:"duration_ms,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	Success    *bool    `json:"success,omitempty"`
+	// Data 命令特有的结果，如审计报告和包比较结果
+	Data interface{} `json:"data,omitempty"`
+}
+
+var (
+	// outputFormat 全局输出格式: text、json 或 ndjson
+	outputFormat string
+	// eventWriter 结构化事件的输出位置，即原始的标准输出
+	eventWriter io.Writer = os.Stdout
+	// eventCommand 当前执行的命令名称
+	eventCommand string
+	// collectedEvents json 模式下收集的事件，命令结束时一次性输出
+	collectedEvents []Event
+)
+
+// setupOutput 根据 --output 配置输出。
+// 结构化模式下标准输出只用于事件，面向用户的提示和 go build 等子进程的输出都改写到标准错误。
+func setupOutput(cmd *cobra.Command) error {
+	switch outputFormat {
+	case "text":
+		return nil
+	case "json", "ndjson":
+	default:
+		cmd.SilenceUsage = true
+		return fmt.Errorf(T("root.invalid_output"), outputFormat)
+	}
+
+	eventCommand = cmd.Name()
+	eventWriter = os.Stdout
+	os.Stdout = os.Stderr
+	return nil
+}
+
+// structuredOutput 判断是否输出结构化事件
+func structuredOutput() bool {
+	return outputFormat == "json" || outputFormat == "ndjson"
+}
+
+// emitEvent 记录一个事件，ndjson 模式下立即输出一行，json 模式下在命令结束时统一输出
+func emitEvent(event Event) {
+	if !structuredOutput() {
+		return
+	}
+	event.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	event.Command = eventCommand
+
+	if outputFormat == "ndjson" {
+		data, _ := json.Marshal(event)
+		fmt.Fprintln(eventWriter, string(data))
+		return
+	}
+	collectedEvents = append(collectedEvents, event)
+}
+
+// emitError 输出错误事件
+func emitError(err error) {
+	emitEvent(Event{Type: EventError, Error: err.Error()})
+}
+
+// printError 输出错误提示，记录错误事件，并使命令以非零状态码退出
+func printError(message string) {
+	logError(message)
+	emitEvent(Event{Type: EventError, Error: message})
+	setExitCode(1)
+}
+
+// finishOutput 在 json 模式下输出包含所有事件的文档，
+// 命令执行过程中出现错误事件或目标、可执行文件构建失败时 success 为 false
+func finishOutput() {
+	if outputFormat != "json" {
+		return
+	}
+
+	success := true
+	for _, event := range collectedEvents {
+		switch event.Type {
+		case EventError, EventTargetFailed, EventExecutableFailed:
+			success = false
+		}
+	}
+	events := collectedEvents
+	if events == nil {
+		events = []Event{}
+	}
+
+	data, _ := json.MarshalIndent(struct {
+		Command string  `json:"command"`
+		Success bool    `json:"success"`
+		Events  []Event `json:"events"`
+	}{eventCommand, success, events}, "", "  ")
+	fmt.Fprintln(eventWriter, string(data))
+}
+
+// boolPtr 返回指向 b 的指针，用于可选的布尔字段
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Executable 描述一个需要构建的可执行文件
+type Executable struct {
+	Name   string `json:"name"`   // 输出的二进制文件名（不含 .exe 后缀）
+	Source string `json:"source"` // main 包所在目录，如 "." 或 "./cmd/server"
+}
+
+// discoverExecutables 确定需要构建的可执行文件列表。
+// 如果 .dscli.json 中配置了 executables，则只使用显式列表；
+// 否则自动发现根目录的 main 包（以项目名称命名）和 cmd 目录下的每个 main 包。
+// 当两个来源映射到同一个二进制文件名时返回错误。
+func discoverExecutables(projectName string) ([]Executable, error) {
+	var executables []Executable
+
+	if buildConfig != nil && len(buildConfig.Executables) > 0 {
+		for _, exe := range buildConfig.Executables {
+			if exe.Name == "" || exe.Source == "" {
+				return nil, errors.New(T("executable.missing_fields"))
+			}
+			if err := validateName(T("name.kind.executable"), exe.Name); err != nil {
+				return nil, err
+			}
+			source := filepath.Clean(exe.Source)
+			if source != "." {
+				if err := ensureWithinDir(".", source); err != nil {
+					return nil, fmt.Errorf(T("executable.invalid_source"), exe.Name, err)
+				}
+				source = "./" + filepath.ToSlash(source)
+			}
+			if !isMainPackage(exe.Source) {
+				return nil, fmt.Errorf(T("executable.not_main"), exe.Name, exe.Source)
+			}
+			executables = append(executables, Executable{
+				Name:   exe.Name,
+				Source: source,
+			})
+		}
+	} else {
+		// 根目录的 main 包以项目名称命名
+		if isMainPackage(".") {
+			executables = append(executables, Executable{Name: projectName, Source: "."})
+		}
+
+		cmdExecutables, err := discoverCmdExecutables()
+		if err != nil {
+			return nil, err
+		}
+		for _, execName := range cmdExecutables {
+			if err := validateName(T("name.kind.executable"), execName); err != nil {
+				return nil, fmt.Errorf("cmd/%s: %w", execName, err)
+			}
+			executables = append(executables, Executable{
+				Name:   execName,
+				Source: "./" + filepath.ToSlash(filepath.Join("cmd", execName)),
+			})
+		}
+	}
+
+	if err := checkExecutableCollisions(executables); err != nil {
+		return nil, err
+	}
+
+	return executables, nil
+}
+
+// checkExecutableCollisions 检查是否有多个来源生成同名的二进制文件。
+// 名称比较不区分大小写，因为 Windows 和 macOS 的文件系统默认不区分大小写。
+func checkExecutableCollisions(executables []Executable) error {
+	seen := make(map[string]Executable)
+	for _, exe := range executables {
+		key := strings.ToLower(exe.Name)
+		if prev, ok := seen[key]; ok {
+			return fmt.Errorf(T("executable.collision"), prev.Source, exe.Source, exe.Name)
+		}
+		seen[key] = exe
+	}
+	return nil
+}
+
+// discoverCmdExecutables 自动发现cmd目录下的子目录，每个子目录代表一个可执行文件
+func discoverCmdExecutables() ([]string, error) {
+	cmdDir := "cmd"
+	if _, err := os.Stat(cmdDir); os.IsNotExist(err) {
+		return []string{}, nil // cmd目录不存在，返回空列表
+	}
+
+	entries, err := os.ReadDir(cmdDir)
+	if err != nil {
+		return nil, fmt.Errorf(T("executable.read_cmd_failed"), err)
+	}
+
+	var executables []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue // 跳过文件，只处理目录
+		}
+
+		execName := entry.Name()
+		// 检查子目录是否是 main 包
+		if isMainPackage(filepath.Join(cmdDir, execName)) {
+			executables = append(executables, execName)
+		}
+	}
+
+	sort.Strings(executables)
+	return executables, nil
+}
+
+// isMainPackage 判断目录中是否包含 package main 的 Go 源文件（忽略测试文件）
+func isMainPackage(dir string) bool {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return false
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+		if f.Name.Name == "main" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiscoverExecutablesCollisions(t *testing.T) {
+	defer func(c *BuildConfig) { buildConfig = c }(buildConfig)
+	buildConfig = nil
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  [3]string // 先发现的来源、冲突的来源、二进制文件名
+	}{
+		{
+			name: "root and cmd/<project>",
+			files: map[string]string{
+				"main.go":          "package main\n",
+				"cmd/demo/main.go": "package main\n",
+			},
+			want: [3]string{".", "./cmd/demo", "demo"},
+		},
+		{
+			name: "case-insensitive cmd directories",
+			files: map[string]string{
+				"cmd/Foo/main.go": "package main\n",
+				"cmd/foo/main.go": "package main\n",
+			},
+			want: [3]string{"./cmd/Foo", "./cmd/foo", "foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+			writeFiles(t, dir, tt.files)
+
+			_, err := discoverExecutables("demo")
+			if err == nil {
+				t.Fatal("colliding executables should be an error")
+			}
+			want := fmt.Sprintf(T("executable.collision"), tt.want[0], tt.want[1], tt.want[2])
+			if err.Error() != want {
+				t.Errorf("error = %q, want %q", err, want)
+			}
+		})
+	}
+}
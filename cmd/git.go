@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// gitAuthorPattern 匹配 "Name <email>" 格式的作者信息
+var gitAuthorPattern = regexp.MustCompile(`^\s*([^<>]+?)\s*<([^<>]+)>\s*$`)
+
+// runGit 在指定目录中执行 git 命令，返回去掉首尾空白的标准输出
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitConfigValue 读取 git 配置项，未配置或 git 不可用时返回空字符串
+func gitConfigValue(key string) string {
+	value, err := runGit(".", "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// gitUserAuthor 从 git config 读取 "Name <email>" 格式的作者信息
+func gitUserAuthor() string {
+	name := gitConfigValue("user.name")
+	email := gitConfigValue("user.email")
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	default:
+		return name
+	}
+}
+
+// parseGitAuthor 解析 "Name <email>" 格式的作者信息
+func parseGitAuthor(author string) (name, email string, err error) {
+	m := gitAuthorPattern.FindStringSubmatch(author)
+	if m == nil {
+		return "", "", fmt.Errorf(T("git.invalid_author"), author)
+	}
+	return m[1], m[2], nil
+}
+
+// initGitRepo 在项目目录中初始化 git 仓库并创建初始提交。
+// author 为空时使用 git config 中的 user.name 和 user.email。
+// 目录已经是 git 仓库时不做任何操作，避免把已有文件混入新的提交。
+func initGitRepo(projectDir, author string) error {
+	if _, err := os.Stat(filepath.Join(projectDir, ".git")); err == nil {
+		logInfo(T("git.already_repo", projectDir))
+		return nil
+	}
+
+	if author == "" {
+		author = gitUserAuthor()
+	}
+	name, email, err := parseGitAuthor(author)
+	if err != nil {
+		return fmt.Errorf(T("git.no_author"), err)
+	}
+
+	if _, err := runGit(projectDir, "init"); err != nil {
+		return err
+	}
+	if _, err := runGit(projectDir, "add", "-A"); err != nil {
+		return err
+	}
+
+	// 通过 -c 指定提交者，避免依赖全局配置，同时禁用签名以保证在无交互环境中可用
+	_, err = runGit(projectDir,
+		"-c", "user.name="+name,
+		"-c", "user.email="+email,
+		"-c", "commit.gpgsign=false",
+		"commit", "--no-verify", "-m", "Initial commit",
+		"--author", fmt.Sprintf("%s <%s>", name, email))
+	if err != nil {
+		return err
+	}
+
+	logInfo(T("git.initialised", name, email))
+	return nil
+}
+
+// gitTagExists 判断当前仓库中是否已有指定的标签
+func gitTagExists(tag string) (bool, error) {
+	out, err := runGit(".", "tag", "--list", "--", tag)
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// gitModifiedFiles 返回工作区和暂存区中未提交修改的已跟踪文件，未跟踪的文件不计入
+func gitModifiedFiles() ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, args := range [][]string{{"diff", "--name-only"}, {"diff", "--name-only", "--cached"}} {
+		out, err := runGit(".", args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range strings.Split(out, "\n") {
+			if file != "" && !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
+
+// gitDescribeVersion 使用 git describe 推导版本号，与 dscli 自身 Makefile 的做法一致。
+// 去掉标签的 "v" 前缀；仓库没有标签时返回 0.0.0-<commit>。
+func gitDescribeVersion() (string, error) {
+	desc, err := runGit(".", "describe", "--tags", "--always", "--dirty")
+	if err != nil {
+		return "", err
+	}
+
+	if v, err := parseSemVer(desc); err == nil {
+		return v.String(), nil
+	}
+	// 没有任何标签时 git describe 只输出提交哈希
+	return "0.0.0-" + desc, nil
+}
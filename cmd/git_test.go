@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateGit 让测试中的 git 命令只使用临时的全局配置，不读取用户和系统配置
+func isolateGit(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	home := t.TempDir()
+	config := filepath.Join(home, ".gitconfig")
+	if err := os.WriteFile(config, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("GIT_CONFIG_GLOBAL", config)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL", "GIT_DIR", "GIT_WORK_TREE"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	return config
+}
+
+// gitOutput 在 dir 中执行 git 命令并返回输出，失败时终止测试
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	output, err := runGit(dir, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func TestInitGitRepoWithAuthor(t *testing.T) {
+	isolateGit(t)
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n", ".gitignore": "bin/\n", "bin/demo": "binary"})
+
+	if err := initGitRepo(dir, "Jane Doe <jane@example.com>"); err != nil {
+		t.Fatalf("initGitRepo: %v", err)
+	}
+
+	if author := gitOutput(t, dir, "log", "-1", "--format=%an <%ae>"); author != "Jane Doe <jane@example.com>" {
+		t.Errorf("commit author = %q", author)
+	}
+	if subject := gitOutput(t, dir, "log", "-1", "--format=%s"); subject != "Initial commit" {
+		t.Errorf("commit subject = %q", subject)
+	}
+	files := gitOutput(t, dir, "ls-files")
+	if !strings.Contains(files, "main.go") || strings.Contains(files, "bin/demo") {
+		t.Errorf("tracked files = %q, want main.go without ignored bin/", files)
+	}
+	if status := gitOutput(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("working tree should be clean after the initial commit, got %q", status)
+	}
+}
+
+func TestInitGitRepoAuthorFromConfig(t *testing.T) {
+	config := isolateGit(t)
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n"})
+
+	// 没有 git 用户信息时必须报错，而不是使用 git 猜测的作者
+	if err := initGitRepo(dir, ""); err == nil || !strings.Contains(err.Error(), "--git-author") {
+		t.Fatalf("initGitRepo without author should fail, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); !os.IsNotExist(err) {
+		t.Fatal("repository should not be created when the author is missing")
+	}
+
+	if err := os.WriteFile(config, []byte("[user]\n\tname = Config User\n\temail = config@example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if author := gitUserAuthor(); author != "Config User <config@example.com>" {
+		t.Fatalf("gitUserAuthor = %q", author)
+	}
+	if err := initGitRepo(dir, ""); err != nil {
+		t.Fatalf("initGitRepo: %v", err)
+	}
+	if author := gitOutput(t, dir, "log", "-1", "--format=%an <%ae>"); author != "Config User <config@example.com>" {
+		t.Errorf("commit author = %q", author)
+	}
+}
+
+func TestInitGitRepoSkipsExistingRepository(t *testing.T) {
+	isolateGit(t)
+	dir := t.TempDir()
+	gitOutput(t, dir, "init")
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n"})
+
+	if err := initGitRepo(dir, "Jane Doe <jane@example.com>"); err != nil {
+		t.Fatalf("initGitRepo: %v", err)
+	}
+	if _, err := runGit(dir, "rev-parse", "HEAD"); err == nil {
+		t.Error("existing repository should not get a new commit")
+	}
+}
+
+func TestCreateWithGit(t *testing.T) {
+	isolateGit(t)
+	chdir(t, t.TempDir())
+	defer func(interactive, git bool, projectAuthor, commitAuthor string) {
+		nonInteractive, initGit, author, gitAuthor = interactive, git, projectAuthor, commitAuthor
+	}(nonInteractive, initGit, author, gitAuthor)
+	nonInteractive, initGit, author, gitAuthor = true, true, "", "Jane Doe <jane@example.com>"
+	goVersion = "1.21"
+	defer func() { goVersion = "" }()
+
+	runCreate("demo", false)
+
+	if author := gitOutput(t, "demo", "log", "-1", "--format=%an <%ae>"); author != "Jane Doe <jane@example.com>" {
+		t.Errorf("commit author = %q", author)
+	}
+	files := gitOutput(t, "demo", "ls-files")
+	for _, name := range []string{"manifest.json", "go.mod", "main.go", ".gitignore"} {
+		if !strings.Contains(files, name) {
+			t.Errorf("%s should be committed, tracked files: %q", name, files)
+		}
+	}
+}
+
+func TestGitDescribeVersion(t *testing.T) {
+	isolateGit(t)
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n"})
+	if err := initGitRepo(dir, "Jane Doe <jane@example.com>"); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := gitOutput(t, dir, "rev-parse", "--short", "HEAD")
+	if v, err := gitDescribeVersion(); err != nil || v != "0.0.0-"+commit {
+		t.Errorf("gitDescribeVersion without tags = %q, %v", v, err)
+	}
+
+	gitOutput(t, dir, "tag", "v1.2.3")
+	if v, err := gitDescribeVersion(); err != nil || v != "1.2.3" {
+		t.Errorf("gitDescribeVersion on tag = %q, %v", v, err)
+	}
+
+	writeFiles(t, dir, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	if v, err := gitDescribeVersion(); err != nil || v != "1.2.3-dirty" {
+		t.Errorf("gitDescribeVersion on dirty tree = %q, %v", v, err)
+	}
+}
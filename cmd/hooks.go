@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// defaultHookTimeout 钩子命令的默认超时时间（秒）
+const defaultHookTimeout = 600
+
+// HooksConfig 构建钩子配置，每个阶段可以是单个命令、命令数组或对象数组
+type HooksConfig struct {
+	BeforeBuild  interface{} `json:"before_build"`  // 开始构建前运行一次，失败时中止构建
+	BeforeTarget interface{} `json:"before_target"` // 每个目标平台编译前运行，失败时跳过该目标
+	AfterTarget  interface{} `json:"after_target"`  // 每个目标平台编译完成、打包前运行，可用于处理 bin/ 下的二进制文件
+	AfterPackage interface{} `json:"after_package"` // 每个包生成后运行
+	AfterBuild   interface{} `json:"after_build"`   // 所有目标构建、生成校验和及签名后运行一次
+}
+
+// Hook 一个钩子命令
+type Hook struct {
+	Command     string
+	Timeout     int  // 超时时间（秒），默认为 600
+	IgnoreError bool // 为 true 时命令失败只输出警告
+}
+
+// parseHooks 解析钩子配置，支持字符串、字符串数组和对象数组三种格式
+func parseHooks(value interface{}) ([]Hook, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []Hook{{Command: v, Timeout: defaultHookTimeout}}, nil
+	case []interface{}:
+		var hooks []Hook
+		for _, item := range v {
+			if command, ok := item.(string); ok {
+				// 字符串格式：使用默认超时，失败时中止
+				hooks = append(hooks, Hook{Command: command, Timeout: defaultHookTimeout})
+			} else if hookMap, ok := item.(map[string]interface{}); ok {
+				// 对象格式：解析command、timeout和ignore_error字段
+				command, ok := hookMap["command"].(string)
+				if !ok || command == "" {
+					return nil, errors.New(T("hooks.command_required"))
+				}
+				hook := Hook{Command: command, Timeout: defaultHookTimeout}
+				if timeout, ok := hookMap["timeout"].(float64); ok && timeout > 0 {
+					hook.Timeout = int(timeout)
+				}
+				if ignoreError, ok := hookMap["ignore_error"].(bool); ok {
+					hook.IgnoreError = ignoreError
+				}
+				hooks = append(hooks, hook)
+			} else {
+				return nil, errors.New(T("hooks.unsupported"))
+			}
+		}
+		return hooks, nil
+	}
+	return nil, errors.New(T("hooks.invalid"))
+}
+
+// validateHooks 在构建开始前检查所有阶段的钩子配置
+func validateHooks(hooks HooksConfig) error {
+	stages := map[string]interface{}{
+		"before_build":  hooks.BeforeBuild,
+		"before_target": hooks.BeforeTarget,
+		"after_target":  hooks.AfterTarget,
+		"after_package": hooks.AfterPackage,
+		"after_build":   hooks.AfterBuild,
+	}
+	for stage, value := range stages {
+		if _, err := parseHooks(value); err != nil {
+			return fmt.Errorf(T("hooks.parse_failed"), stage, err)
+		}
+	}
+	return nil
+}
+
+// hookEnv 返回描述当前构建的环境变量，extra 为阶段相关的附加变量
+func hookEnv(stage, projectName, distDir string, extra ...string) []string {
+	version := buildVersion
+	if version == "" {
+		if manifest, err := readManifest(); err == nil {
+			version, _ = manifest["version"].(string)
+		}
+	}
+	absDist, _ := filepath.Abs(distDir)
+	absStaging, _ := filepath.Abs("bin")
+
+	env := append(os.Environ(),
+		"DSCLI_HOOK="+stage,
+		"DSCLI_PROJECT_NAME="+projectName,
+		"DSCLI_VERSION="+version,
+		"DSCLI_OUTPUT_DIR="+absDist,
+		"DSCLI_STAGING_DIR="+absStaging,
+	)
+	return append(env, extra...)
+}
+
+// targetHookEnv 返回目标平台相关的环境变量
+func targetHookEnv(target BuildTarget) []string {
+	return []string{
+		"DSCLI_TARGET_OS=" + target.OS,
+		"DSCLI_TARGET_ARCH=" + target.Arch,
+		"DSCLI_TARGET=" + target.OS + "/" + target.Arch,
+	}
+}
+
+// runHooks 依次运行某个阶段配置的钩子命令，命令在项目根目录下通过 shell 执行
+func runHooks(stage string, value interface{}, env []string) error {
+	hooks, err := parseHooks(value)
+	if err != nil {
+		return fmt.Errorf(T("hooks.parse_failed"), stage, err)
+	}
+
+	for _, hook := range hooks {
+		logInfo(T("hooks.running", stage, hook.Command))
+		if err := runHook(hook, env); err != nil {
+			if hook.IgnoreError {
+				logWarn(T("hooks.failed_ignored", stage, err))
+				continue
+			}
+			return fmt.Errorf(T("hooks.failed"), stage, err)
+		}
+	}
+	return nil
+}
+
+// hookWaitDelay 钩子超时终止后等待输出管道关闭的最长时间
+const hookWaitDelay = 5 * time.Second
+
+// runHook 运行单个钩子命令，超时后终止进程及其启动的子进程
+func runHook(hook Hook, env []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(hook.Timeout)*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	}
+	cmd.Env = env
+	cmd.Stdout, cmd.Stderr = commandOutput()
+	// 超时后终止整个进程组，避免 shell 启动的子进程继续运行并占用输出管道
+	killProcessGroupOnCancel(cmd)
+	cmd.WaitDelay = hookWaitDelay
+	var hookVars []string
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "DSCLI_") {
+			hookVars = append(hookVars, kv)
+		}
+	}
+	logTrace(T("log.hook_env", strings.Join(hookVars, " ")))
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf(T("hooks.timeout"), hook.Timeout)
+	}
+	return err
+}
+
+// binaryPathsEnv 返回已构建二进制文件的绝对路径列表，以空格分隔
+func binaryPathsEnv(builtBinaries []string) string {
+	var paths []string
+	for _, binaryName := range builtBinaries {
+		path, _ := filepath.Abs(filepath.Join("bin", binaryName))
+		paths = append(paths, path)
+	}
+	return "DSCLI_BINARIES=" + strings.Join(paths, " ")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHookTimeoutKillsChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("需要 sh")
+	}
+	// 写入日志文件时子进程的输出经过管道，子进程不退出会使 Wait 一直阻塞
+	file, err := os.Create(filepath.Join(t.TempDir(), "build.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(saved *os.File) { logFile = saved }(logFile)
+	logFile = file
+	defer file.Close()
+
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	start := time.Now()
+	err = runHook(Hook{Command: "sleep 30 & echo $! > " + pidFile + "; wait", Timeout: 1}, os.Environ())
+	if elapsed := time.Since(start); elapsed > hookWaitDelay {
+		t.Errorf("runHook returned after %s, want the hook killed at the timeout", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), T("hooks.timeout", 1)) {
+		t.Fatalf("runHook error = %v, want timeout", err)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid := strings.TrimSpace(string(data))
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat("/proc/" + pid); os.IsNotExist(err) || runtime.GOOS != "linux" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("child process %s is still running after the hook timed out", pid)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// killProcessGroupOnCancel 取消时使用 taskkill /T 终止命令及其所有子进程
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	langZhCN = "zh-CN"
+	langEn   = "en"
+	// defaultLang 未指定语言或语言不受支持时使用的语言
+	defaultLang = langZhCN
+)
+
+// catalogs 各语言的消息目录，键为消息 ID，值为 fmt 格式字符串。
+// 命令和标志的说明以 "cmd.<命令路径>.short|long" 和 "flag.<命令路径>.<标志名>" 为键，
+// 缺失时保留代码中的中文说明。
+var catalogs = map[string]map[string]string{
+	langZhCN: messagesZhCN,
+	langEn:   messagesEn,
+}
+
+var (
+	// langFlag 全局 --lang 标志
+	langFlag string
+	// currentLang 当前使用的语言
+	currentLang = defaultLang
+)
+
+// T 返回当前语言的消息，有参数时按 fmt 格式化。
+// 当前语言缺少该消息时回退到默认语言，仍然缺失时返回消息 ID。
+func T(key string, args ...interface{}) string {
+	msg, ok := catalogs[currentLang][key]
+	if !ok {
+		msg, ok = catalogs[defaultLang][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// normalizeLang 将 zh_CN.UTF-8、en_US、zh-Hans 等写法转换为支持的语言，不支持时返回空字符串
+func normalizeLang(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	switch {
+	case value == "zh" || strings.HasPrefix(value, "zh_") || strings.HasPrefix(value, "zh-"):
+		return langZhCN
+	case value == "en" || strings.HasPrefix(value, "en_") || strings.HasPrefix(value, "en-"):
+		return langEn
+	}
+	return ""
+}
+
+// detectLang 按 --lang、DSCLI_LANG、LC_ALL、LC_MESSAGES、LANG 的顺序确定语言。
+// cobra 在解析标志之前就需要命令说明，所以直接从命令行参数中查找 --lang。
+func detectLang(args []string) string {
+	candidates := []string{langFromArgs(args), os.Getenv("DSCLI_LANG"), os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, candidate := range candidates {
+		if lang := normalizeLang(candidate); lang != "" {
+			return lang
+		}
+	}
+	return defaultLang
+}
+
+// langFromArgs 从命令行参数中查找 --lang 的值
+func langFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return value
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// setupLanguage 确定语言并翻译所有命令和标志的说明。
+// 自定义的 help 命令在执行时才加入命令树，这里提前加入以便一起翻译。
+func setupLanguage(args []string) {
+	currentLang = detectLang(args)
+	rootCmd.InitDefaultHelpCmd()
+	localizeCommand(rootCmd)
+}
+
+// checkLangFlag 校验 --lang 的取值
+func checkLangFlag(cmd *cobra.Command) error {
+	if langFlag != "" && normalizeLang(langFlag) == "" {
+		cmd.SilenceUsage = true
+		return fmt.Errorf(T("root.unsupported_lang"), langFlag)
+	}
+	return nil
+}
+
+// localizeCommand 递归替换命令的 Short、Long 和标志说明
+func localizeCommand(cmd *cobra.Command) {
+	path := "root"
+	if cmd != rootCmd {
+		path = strings.ReplaceAll(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "), " ", ".")
+	}
+
+	if msg, ok := catalogs[currentLang]["cmd."+path+".short"]; ok {
+		cmd.Short = msg
+	}
+	if msg, ok := catalogs[currentLang]["cmd."+path+".long"]; ok {
+		cmd.Long = msg
+	}
+
+	localizeFlags := func(flags *pflag.FlagSet) {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			if msg, ok := catalogs[currentLang]["flag."+path+"."+flag.Name]; ok {
+				flag.Usage = msg
+			}
+		})
+	}
+	localizeFlags(cmd.Flags())
+	localizeFlags(cmd.PersistentFlags())
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// parseSourceFiles 解析包中除测试外的所有源文件
+func parseSourceFiles(t *testing.T) (*token.FileSet, []*ast.File) {
+	t.Helper()
+	names, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	return fset, files
+}
+
+// TestCatalogKeys 检查源码中 T("...") 使用的每个键在中英文消息目录中都存在
+func TestCatalogKeys(t *testing.T) {
+	fset, files := parseSourceFiles(t)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "T" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			key, _ := strconv.Unquote(lit.Value)
+			if _, ok := messagesZhCN[key]; !ok {
+				t.Errorf("%s: %q 不在中文消息目录中", fset.Position(lit.Pos()), key)
+			}
+			if _, ok := messagesEn[key]; !ok {
+				t.Errorf("%s: %q 不在英文消息目录中", fset.Position(lit.Pos()), key)
+			}
+			return true
+		})
+	}
+}
+
+// messageFuncs 输出消息或构造错误的函数，它们的格式字符串必须来自消息目录
+var messageFuncs = map[string]bool{
+	"fmt.Errorf": true, "fmt.Sprintf": true, "errors.New": true,
+	"logError": true, "logWarn": true, "logInfo": true, "logDebug": true, "logTrace": true, "printError": true,
+}
+
+// TestNoHardcodedMessages 检查消息和错误中没有直接写在源码里的中文文本
+func TestNoHardcodedMessages(t *testing.T) {
+	fset, files := parseSourceFiles(t)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			name := ""
+			switch fun := call.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				if pkg, ok := fun.X.(*ast.Ident); ok {
+					name = pkg.Name + "." + fun.Sel.Name
+				}
+			}
+			if !messageFuncs[name] {
+				return true
+			}
+			for _, arg := range call.Args {
+				lit, ok := arg.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				if value, _ := strconv.Unquote(lit.Value); strings.IndexFunc(value, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0 {
+					t.Errorf("%s: %s 中的 %s 应移到消息目录中", fset.Position(lit.Pos()), name, lit.Value)
+				}
+			}
+			return true
+		})
+	}
+}
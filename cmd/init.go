@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// initCmd 代表 init 命令
+var initCmd = &cobra.Command{
+	Use:   "init [project-name]",
+	Short: "在当前目录中初始化 dsserv 模块项目",
+	Long: `在当前目录中生成 dsserv 模块项目的脚手架文件。
+项目名称默认为当前目录名。当前目录不为空时需要使用 --force 或 --merge。`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var projectName string
+		if len(args) > 0 {
+			projectName = args[0]
+		} else {
+			wd, err := os.Getwd()
+			if err != nil {
+				printError(T("init.getwd_failed", err))
+				return
+			}
+			projectName = filepath.Base(wd)
+		}
+		runCreate(projectName, true)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	addCreateFlags(initCmd)
+}
@@ -0,0 +1,543 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// currentLinkName 指向当前版本的符号链接名称
+const currentLinkName = "current"
+
+var (
+	modulesDir       string
+	installRepo      string
+	installForce     bool
+	installTrustKeys []string
+	installInsecure  bool
+	rollbackTo       string
+)
+
+// installCmd 代表 install 命令
+var installCmd = &cobra.Command{
+	Use:   "install <package|name@version>",
+	Short: "将模块包安装到 Agent 模块目录",
+	Long: `校验模块包的校验和与签名，检查包的 os/arch 是否与本机一致，
+安全地解压到 <modules-dir>/<name>/<version>，并原子地切换 current 符号链接。
+参数可以是本地的 .tar.gz 包，也可以是 name@version（version 可以是 latest），
+后者从 --repo 指定的本地模块仓库的 index.json 中解析。
+包必须由 --trusted 或 signing.trusted_keys 中的公钥签署，使用 --insecure 才能安装未经签名校验的包。`,
+	Example: `  dscli install dist/my-module_linux_amd64.tar.gz
+  dscli install my-module@1.2.0 --repo /srv/modules
+  dscli install my-module@latest --repo /srv/modules --dir /opt/dsserv/modules`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+		if err := installModule(args[0]); err != nil {
+			printError(T("install.failed", err))
+			return
+		}
+	},
+}
+
+// uninstallCmd 代表 uninstall 命令
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall <name[@version]>",
+	Short: "从 Agent 模块目录中卸载模块",
+	Long: `卸载模块的指定版本，未指定版本时卸载模块的所有版本。
+卸载当前正在使用的版本需要 --force。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+		if err := uninstallModule(args[0]); err != nil {
+			printError(T("install.uninstall_failed", err))
+			return
+		}
+	},
+}
+
+// rollbackCmd 代表 rollback 命令
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <name>",
+	Short: "将模块回滚到之前安装的版本",
+	Long: `将模块的 current 符号链接切换到已安装的较低版本。
+默认切换到低于当前版本的最高版本，使用 --to 指定目标版本。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+		if err := rollbackModule(args[0]); err != nil {
+			printError(T("install.rollback_failed", err))
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(uninstallCmd)
+	rootCmd.AddCommand(rollbackCmd)
+
+	for _, cmd := range []*cobra.Command{installCmd, uninstallCmd, rollbackCmd} {
+		cmd.Flags().StringVar(&modulesDir, "dir", "", "Agent 模块目录 (默认取 DSCLI_MODULES_DIR、.dscli.json 的 modules_dir 或 ./modules)")
+	}
+	installCmd.Flags().StringVar(&installRepo, "repo", "", "本地模块仓库目录 (包含 index.json)，用于解析 name@version")
+	installCmd.Flags().BoolVar(&installForce, "force", false, "重新安装已存在的版本")
+	installCmd.Flags().StringSliceVar(&installTrustKeys, "trusted", nil, "受信任的公钥文件或目录 (可重复指定)")
+	installCmd.Flags().BoolVar(&installInsecure, "insecure", false, "未配置受信任的公钥时仍然安装未经签名校验的包")
+	uninstallCmd.Flags().BoolVar(&installForce, "force", false, "允许卸载当前正在使用的版本")
+	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "回滚到指定版本")
+}
+
+// resolveModulesDir 确定 Agent 模块目录
+func resolveModulesDir() string {
+	if modulesDir != "" {
+		return modulesDir
+	}
+	if dir := os.Getenv("DSCLI_MODULES_DIR"); dir != "" {
+		return dir
+	}
+	if buildConfig != nil && buildConfig.ModulesDir != "" {
+		return buildConfig.ModulesDir
+	}
+	return "modules"
+}
+
+func installModule(arg string) error {
+	packagePath := arg
+	expectedDigest := ""
+
+	// name@version 从本地模块仓库解析
+	if !strings.HasSuffix(arg, ".tar.gz") && strings.Contains(arg, "@") {
+		var err error
+		if packagePath, expectedDigest, err = resolveFromRepo(arg); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(packagePath); err != nil {
+		return err
+	}
+
+	// 校验完整性
+	if expectedDigest != "" {
+		actual, err := fileSHA256(packagePath)
+		if err != nil {
+			return err
+		}
+		if actual != expectedDigest {
+			return fmt.Errorf(T("install.index_checksum_mismatch"), packagePath, expectedDigest, actual)
+		}
+		logInfo(T("sign.checksum_matched", "index.json"))
+	} else if found, err := verifyChecksum(packagePath); err != nil {
+		return err
+	} else if found {
+		logInfo(T("sign.checksum_matched", checksumFileName))
+	} else {
+		logWarn(T("install.no_checksum"))
+	}
+
+	// 必须通过签名校验，只有显式指定 --insecure 且未配置受信任公钥时才跳过
+	trusted := append(append([]string{}, installTrustKeys...), buildConfig.Signing.TrustedKeys...)
+	if len(trusted) > 0 {
+		keys, err := loadTrustedKeys(trusted)
+		if err != nil {
+			return err
+		}
+		fingerprint, err := verifySignature(packagePath, keys)
+		if err != nil {
+			return err
+		}
+		logInfo(T("sign.signed_by", fingerprint))
+	} else if installInsecure {
+		logWarn(T("install.insecure"))
+	} else {
+		return errors.New(T("install.no_trusted_keys"))
+	}
+
+	manifest, err := readPackageManifest(packagePath)
+	if err != nil {
+		return err
+	}
+	name, _ := manifest["name"].(string)
+	version, _ := manifest["version"].(string)
+	goos, _ := manifest["os"].(string)
+	arch, _ := manifest["arch"].(string)
+	if err := validateName(T("name.kind.module"), name); err != nil {
+		return err
+	}
+	if version == "" || strings.ContainsAny(version, `/\`) || strings.Contains(version, "..") || version == currentLinkName {
+		return fmt.Errorf(T("install.invalid_version"), version)
+	}
+	if goos != runtime.GOOS || arch != runtime.GOARCH {
+		return fmt.Errorf(T("install.platform_mismatch"), goos, arch, runtime.GOOS, runtime.GOARCH)
+	}
+
+	moduleDir := filepath.Join(resolveModulesDir(), name)
+	versionDir := filepath.Join(moduleDir, version)
+	if _, err := os.Stat(versionDir); err == nil {
+		if !installForce {
+			return fmt.Errorf(T("install.already_installed"), name, version)
+		}
+	}
+	if err := os.MkdirAll(moduleDir, 0755); err != nil {
+		return err
+	}
+
+	// 先解压到临时目录，成功后再替换版本目录
+	stagingDir, err := os.MkdirTemp(moduleDir, "."+version+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+	if err := os.Chmod(stagingDir, 0755); err != nil {
+		return err
+	}
+
+	if err := extractPackage(packagePath, stagingDir); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(versionDir); err != nil {
+		return err
+	}
+	if err := os.Rename(stagingDir, versionDir); err != nil {
+		return err
+	}
+
+	if err := switchCurrent(moduleDir, version); err != nil {
+		return err
+	}
+
+	logInfo(T("install.done", name, version, versionDir))
+	return nil
+}
+
+// resolveFromRepo 从本地模块仓库索引中解析 name@version，返回包路径和期望的摘要
+func resolveFromRepo(spec string) (string, string, error) {
+	name, version, _ := strings.Cut(spec, "@")
+	repoDir := installRepo
+	if repoDir == "" && buildConfig != nil {
+		repoDir = buildConfig.Repo
+	}
+	if repoDir == "" {
+		return "", "", fmt.Errorf(T("install.repo_required"), spec)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoDir, repoIndexFileName))
+	if err != nil {
+		return "", "", fmt.Errorf(T("install.read_index_failed"), err)
+	}
+	var index RepoIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return "", "", fmt.Errorf(T("install.parse_index_failed"), err)
+	}
+
+	module, ok := index.Modules[name]
+	if !ok {
+		return "", "", fmt.Errorf(T("install.module_not_found"), name)
+	}
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	if version == "" || version == "latest" {
+		version = module.LatestForPlatform[platform]
+		if version == "" {
+			return "", "", fmt.Errorf(T("install.no_platform_version"), name, platform)
+		}
+	}
+
+	artifact, ok := module.Versions[version][platform]
+	if !ok {
+		return "", "", fmt.Errorf(T("install.artifact_not_found"), name, version, platform)
+	}
+
+	packagePath := filepath.Join(repoDir, filepath.FromSlash(artifact.File))
+	if err := ensureWithinDir(repoDir, packagePath); err != nil {
+		return "", "", err
+	}
+	logInfo(T("install.resolved", name, version, artifact.File))
+	return packagePath, artifact.SHA256, nil
+}
+
+// extractPackage 安全地解压模块包到目标目录。
+// 拒绝绝对路径、包含 ".." 的路径、经过符号链接写入的路径以及硬链接和设备文件；
+// 解压完成后在文件系统上解析每个符号链接，拒绝指向目录外部或不存在的目标。
+func extractPackage(packagePath, destDir string) error {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return checkSymlinks(destDir)
+		}
+		if err != nil {
+			return err
+		}
+
+		name, err := safeArchivePath(header.Name)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			continue
+		}
+		// 不允许通过已解压的符号链接写入文件，包括与符号链接同名的条目
+		if err := checkNoSymlinkInPath(destDir, name); err != nil {
+			return fmt.Errorf(T("install.entry_error"), header.Name, err)
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tarReader); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if path.IsAbs(header.Linkname) || filepath.IsAbs(header.Linkname) {
+				return fmt.Errorf(T("install.symlink_absolute"), header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf(T("install.unsupported_type"), header.Name, header.Typeflag)
+		}
+	}
+}
+
+// checkNoSymlinkInPath 检查包内路径 name 本身及其上级目录在 root 下都不是符号链接
+func checkNoSymlinkInPath(root, name string) error {
+	elems := strings.Split(name, "/")
+	for i := range elems {
+		prefix := path.Join(elems[:i+1]...)
+		info, err := os.Lstat(filepath.Join(root, filepath.FromSlash(prefix)))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf(T("install.through_symlink"), prefix)
+		}
+	}
+	return nil
+}
+
+// checkSymlinks 在文件系统上解析 root 下的所有符号链接，拒绝指向 root 外部或不存在的目标。
+// 符号链接可能相互引用 (如 b -> . 和 evil -> b/../../x)，只按路径文本判断无法发现越界。
+func checkSymlinks(root string) error {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		resolved, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return fmt.Errorf(T("install.symlink_unresolved"), filepath.ToSlash(rel), err)
+		}
+		if err := ensureWithinDir(realRoot, resolved); err != nil {
+			return fmt.Errorf(T("install.symlink_escapes"), filepath.ToSlash(rel))
+		}
+		return nil
+	})
+}
+
+// safeArchivePath 规范化包内路径，拒绝绝对路径和路径穿越
+func safeArchivePath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf(T("install.absolute_path"), name)
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return "", fmt.Errorf(T("install.illegal_path"), name)
+		}
+	}
+	return path.Clean(name), nil
+}
+
+// switchCurrent 原子地将 current 符号链接指向指定版本：先创建临时链接，再重命名覆盖
+func switchCurrent(moduleDir, version string) error {
+	tmpLink := filepath.Join(moduleDir, "."+currentLinkName+".tmp")
+	os.Remove(tmpLink)
+	if err := os.Symlink(version, tmpLink); err != nil {
+		return fmt.Errorf(T("install.symlink_failed"), err)
+	}
+	if err := os.Rename(tmpLink, filepath.Join(moduleDir, currentLinkName)); err != nil {
+		os.Remove(tmpLink)
+		return fmt.Errorf(T("install.switch_failed"), err)
+	}
+	return nil
+}
+
+// currentVersion 返回 current 符号链接指向的版本，未安装时返回空字符串
+func currentVersion(moduleDir string) string {
+	target, err := os.Readlink(filepath.Join(moduleDir, currentLinkName))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+// installedVersions 返回模块已安装的所有版本，按版本从低到高排序
+func installedVersions(moduleDir string) ([]string, error) {
+	entries, err := os.ReadDir(moduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersionStrings(versions[i], versions[j]) < 0
+	})
+	return versions, nil
+}
+
+// compareVersionStrings 按语义化版本比较，无法解析时按字符串比较
+func compareVersionStrings(a, b string) int {
+	av, aErr := parseSemVer(a)
+	bv, bErr := parseSemVer(b)
+	if aErr != nil || bErr != nil {
+		return strings.Compare(a, b)
+	}
+	return av.Compare(bv)
+}
+
+func uninstallModule(spec string) error {
+	name, version, _ := strings.Cut(spec, "@")
+	if err := validateName(T("name.kind.module"), name); err != nil {
+		return err
+	}
+	moduleDir := filepath.Join(resolveModulesDir(), name)
+	if _, err := os.Stat(moduleDir); err != nil {
+		return fmt.Errorf(T("install.module_not_installed"), name)
+	}
+
+	if version == "" {
+		if err := os.RemoveAll(moduleDir); err != nil {
+			return err
+		}
+		logInfo(T("install.uninstalled_all", name))
+		return nil
+	}
+
+	versionDir := filepath.Join(moduleDir, version)
+	if err := ensureWithinDir(moduleDir, versionDir); err != nil {
+		return err
+	}
+	if _, err := os.Stat(versionDir); err != nil {
+		return fmt.Errorf(T("install.version_not_installed"), name, version)
+	}
+	isCurrent := currentVersion(moduleDir) == version
+	if isCurrent && !installForce {
+		return fmt.Errorf(T("install.version_in_use"), name, version)
+	}
+
+	if err := os.RemoveAll(versionDir); err != nil {
+		return err
+	}
+	if isCurrent {
+		os.Remove(filepath.Join(moduleDir, currentLinkName))
+		logWarn(T("install.current_removed", name))
+	}
+	logInfo(T("install.uninstalled", name, version))
+	return nil
+}
+
+func rollbackModule(name string) error {
+	if err := validateName(T("name.kind.module"), name); err != nil {
+		return err
+	}
+	moduleDir := filepath.Join(resolveModulesDir(), name)
+	versions, err := installedVersions(moduleDir)
+	if err != nil {
+		return fmt.Errorf(T("install.module_not_installed"), name)
+	}
+	current := currentVersion(moduleDir)
+
+	target := rollbackTo
+	if target == "" {
+		for _, v := range versions {
+			if current == "" || compareVersionStrings(v, current) < 0 {
+				target = v
+			}
+		}
+		if target == "" {
+			return fmt.Errorf(T("install.no_lower_version"), current)
+		}
+	} else {
+		found := false
+		for _, v := range versions {
+			if v == target {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf(T("install.version_not_installed"), name, target)
+		}
+	}
+
+	if err := switchCurrent(moduleDir, target); err != nil {
+		return err
+	}
+	logInfo(T("install.rolled_back", name, current, target))
+	return nil
+}
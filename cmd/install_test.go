@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// tarEntry 测试用模块包中的一个条目，Linkname 非空时为符号链接
+type tarEntry struct {
+	Name     string
+	Body     string
+	Linkname string
+	Type     byte
+}
+
+// writeTestPackage 将条目按顺序写入 tar.gz 文件
+func writeTestPackage(t *testing.T, packagePath string, entries []tarEntry) {
+	t.Helper()
+	file, err := os.Create(packagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.Name, Mode: 0644, Typeflag: entry.Type}
+		switch {
+		case entry.Linkname != "":
+			header.Typeflag, header.Linkname, header.Mode = tar.TypeSymlink, entry.Linkname, 0777
+		case entry.Type == 0:
+			header.Typeflag, header.Size = tar.TypeReg, int64(len(entry.Body))
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(entry.Body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testManifest 返回与本机平台一致的模块包 manifest.json
+func testManifest(t *testing.T, name, version string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]string{"name": name, "version": version, "os": runtime.GOOS, "arch": runtime.GOARCH})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestExtractPackageRejectsMaliciousEntries(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name:    "parent traversal",
+			entries: []tarEntry{{Name: "../outside.txt", Body: "pwned"}},
+		},
+		{
+			name:    "absolute path",
+			entries: []tarEntry{{Name: "/tmp/dscli-absolute-test.txt", Body: "pwned"}},
+		},
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{{Name: "link", Linkname: "/etc"}},
+		},
+		{
+			name:    "textual symlink escape",
+			entries: []tarEntry{{Name: "link", Linkname: "../outside.txt"}},
+		},
+		{
+			name: "chained symlink escape",
+			entries: []tarEntry{
+				{Name: "d/b", Linkname: ".."},
+				{Name: "d/evil", Linkname: "b/../../outside.txt"},
+			},
+		},
+		{
+			name: "regular file through chained symlink",
+			entries: []tarEntry{
+				{Name: "d/b", Linkname: ".."},
+				{Name: "d/evil", Linkname: "b/../../outside.txt"},
+				{Name: "d/evil", Body: "pwned"},
+			},
+		},
+		{
+			name: "write through symlinked directory",
+			entries: []tarEntry{
+				{Name: "dir", Linkname: "."},
+				{Name: "dir/file.txt", Body: "data"},
+			},
+		},
+		{
+			name: "regular file replacing symlink",
+			entries: []tarEntry{
+				{Name: "bin/app", Linkname: "../../outside.txt"},
+				{Name: "bin/app", Body: "pwned"},
+			},
+		},
+		{
+			name: "regular file replacing in-package symlink",
+			entries: []tarEntry{
+				{Name: "config.json", Body: "{}"},
+				{Name: "alias.json", Linkname: "config.json"},
+				{Name: "alias.json", Body: "pwned"},
+			},
+		},
+		{
+			name:    "hard link",
+			entries: []tarEntry{{Name: "hard", Linkname: "", Type: tar.TypeLink}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			outside := filepath.Join(root, "outside.txt")
+			if err := os.WriteFile(outside, []byte("original"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dest := filepath.Join(root, "modules", "staging")
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				t.Fatal(err)
+			}
+			packagePath := filepath.Join(root, "evil.tar.gz")
+			writeTestPackage(t, packagePath, tt.entries)
+
+			if err := extractPackage(packagePath, dest); err == nil {
+				t.Fatal("extractPackage should reject the package")
+			}
+			if data, _ := os.ReadFile(outside); string(data) != "original" {
+				t.Fatalf("file outside the staging directory was modified: %q", data)
+			}
+			if _, err := os.Stat("/tmp/dscli-absolute-test.txt"); err == nil {
+				os.Remove("/tmp/dscli-absolute-test.txt")
+				t.Fatal("absolute path was written")
+			}
+		})
+	}
+}
+
+func TestExtractPackageAllowsInternalSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	root := t.TempDir()
+	packagePath := filepath.Join(root, "ok.tar.gz")
+	writeTestPackage(t, packagePath, []tarEntry{
+		{Name: "manifest.json", Body: "{}"},
+		{Name: "bin/", Type: tar.TypeDir},
+		{Name: "bin/app", Body: "binary"},
+		{Name: "bin/app-latest", Linkname: "app"},
+		{Name: "lib/current", Linkname: "../bin"},
+	})
+
+	dest := filepath.Join(root, "staging")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractPackage(packagePath, dest); err != nil {
+		t.Fatalf("extractPackage: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "lib", "current", "app-latest")); err != nil || string(data) != "binary" {
+		t.Fatalf("internal symlinks should resolve, got %q, %v", data, err)
+	}
+}
+
+func TestInstallModuleRequiresSignature(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	defer func(config *BuildConfig, insecure bool, dir string) {
+		buildConfig, installInsecure, modulesDir = config, insecure, dir
+	}(buildConfig, installInsecure, modulesDir)
+	buildConfig = &BuildConfig{}
+	modulesDir = filepath.Join(dir, "modules")
+
+	packagePath := filepath.Join(dir, "demo.tar.gz")
+	writeTestPackage(t, packagePath, []tarEntry{{Name: "manifest.json", Body: testManifest(t, "demo", "1.0.0")}})
+
+	installInsecure = false
+	err := installModule(packagePath)
+	if err == nil || !strings.Contains(err.Error(), "--insecure") {
+		t.Fatalf("unsigned install without trusted keys should fail closed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(modulesDir, "demo", "1.0.0")); !os.IsNotExist(err) {
+		t.Fatal("package should not be installed")
+	}
+
+	installInsecure = true
+	if err := installModule(packagePath); err != nil {
+		t.Fatalf("--insecure install: %v", err)
+	}
+	if version := currentVersion(filepath.Join(modulesDir, "demo")); version != "1.0.0" {
+		t.Fatalf("current version = %q, want 1.0.0", version)
+	}
+}
+
+func TestInstallModuleVerifiesSignature(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	defer func(config *BuildConfig, keys []string, dir, key string, force bool) {
+		buildConfig, installTrustKeys, modulesDir, signKeyFile, installForce = config, keys, dir, key, force
+	}(buildConfig, installTrustKeys, modulesDir, signKeyFile, installForce)
+	buildConfig = &BuildConfig{}
+	modulesDir = filepath.Join(dir, "modules")
+
+	if err := generateKeyPair("release"); err != nil {
+		t.Fatal(err)
+	}
+	packagePath := filepath.Join(dir, "demo.tar.gz")
+	writeTestPackage(t, packagePath, []tarEntry{{Name: "manifest.json", Body: testManifest(t, "demo", "1.0.0")}})
+	signKeyFile = "release.key"
+	if err := signFiles([]string{packagePath}); err != nil {
+		t.Fatal(err)
+	}
+
+	installTrustKeys = []string{"release.pub"}
+	if err := installModule(packagePath); err != nil {
+		t.Fatalf("signed install: %v", err)
+	}
+
+	// 签名之后被替换的包必须被拒绝
+	writeTestPackage(t, packagePath, []tarEntry{{Name: "manifest.json", Body: testManifest(t, "demo", "1.0.0")}, {Name: "bin/extra", Body: "x"}})
+	installForce = true
+	if err := installModule(packagePath); err == nil {
+		t.Fatal("tampered package should fail signature verification")
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+// LogLevel 日志级别，数值越大输出越详细
+type LogLevel int
+
+const (
+	LogError LogLevel = iota
+	LogWarn
+	LogInfo
+	LogDebug
+	LogTrace
+)
+
+// logLevelNames 日志文件中使用的级别名称
+var logLevelNames = map[LogLevel]string{
+	LogError: "ERROR",
+	LogWarn:  "WARN",
+	LogInfo:  "INFO",
+	LogDebug: "DEBUG",
+	LogTrace: "TRACE",
+}
+
+// logColors 终端中各级别使用的 ANSI 颜色，信息级别不着色
+var logColors = map[LogLevel]string{
+	LogError: "\033[31m",
+	LogWarn:  "\033[33m",
+	LogDebug: "\033[2m",
+	LogTrace: "\033[2m",
+}
+
+var (
+	// verbosity 全局 -v 标志的次数，-v 输出调试信息，-vv 输出跟踪信息
+	verbosity int
+	// quietOutput 全局 -q 标志，只输出警告和错误
+	quietOutput bool
+	// logFilePath 全局 --log-file 标志
+	logFilePath string
+	// logLevel 终端输出的日志级别
+	logLevel = LogInfo
+	// plainOutput 为 true 时不输出 emoji 和颜色
+	plainOutput bool
+	// logFile 完整构建记录的日志文件，不受 -q 和 -v 影响
+	logFile *os.File
+)
+
+// setupLogging 根据 -v、-q、--log-file 和 NO_COLOR 配置日志。
+// 需要在 setupOutput 之后调用，以便按实际的输出位置判断是否为终端。
+func setupLogging(cmd *cobra.Command) error {
+	if quietOutput && verbosity > 0 {
+		cmd.SilenceUsage = true
+		return errors.New(T("log.verbose_quiet"))
+	}
+	switch {
+	case quietOutput:
+		logLevel = LogWarn
+	case verbosity == 1:
+		logLevel = LogDebug
+	case verbosity >= 2:
+		logLevel = LogTrace
+	}
+
+	_, noColor := os.LookupEnv("NO_COLOR")
+	plainOutput = noColor || !isTerminal(os.Stdout)
+
+	if logFilePath != "" {
+		file, err := os.Create(logFilePath)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf(T("log.open_failed"), err)
+		}
+		logFile = file
+		writeLogFile(LogInfo, "dscli "+strings.Join(os.Args[1:], " "))
+	}
+	return nil
+}
+
+// closeLogging 关闭日志文件
+func closeLogging() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+}
+
+// isTerminal 判断文件是否连接到终端
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// logMessage 按级别输出一条消息，同时写入日志文件
+func logMessage(level LogLevel, message string) {
+	writeLogFile(level, message)
+	if level > logLevel {
+		return
+	}
+
+	if plainOutput {
+		message = stripEmoji(message)
+	} else if color, ok := logColors[level]; ok {
+		// 开头的空行不着色，避免颜色延续到空行上
+		trimmed := strings.TrimLeft(message, "\n")
+		message = message[:len(message)-len(trimmed)] + color + trimmed + "\033[0m"
+	}
+	if progress != nil {
+		progress.println(level, message)
+		return
+	}
+	fmt.Println(message)
+}
+
+func logError(message string) { logMessage(LogError, message) }
+func logWarn(message string)  { logMessage(LogWarn, message) }
+func logInfo(message string)  { logMessage(LogInfo, message) }
+func logDebug(message string) { logMessage(LogDebug, message) }
+func logTrace(message string) { logMessage(LogTrace, message) }
+
+// writeLogFile 将消息以时间和级别为前缀写入日志文件，多行消息的每一行都带前缀
+func writeLogFile(level LogLevel, message string) {
+	if logFile == nil {
+		return
+	}
+	prefix := fmt.Sprintf("%s %-5s ", time.Now().Format(time.RFC3339), logLevelNames[level])
+	for _, line := range strings.Split(strings.Trim(stripEmoji(message), "\n"), "\n") {
+		fmt.Fprintln(logFile, prefix+line)
+	}
+}
+
+// commandOutput 返回子进程的标准输出和标准错误，配置了日志文件时同时写入日志文件，
+// 显示构建进度时输出在进度块上方
+func commandOutput() (io.Writer, io.Writer) {
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	if progress != nil {
+		stdout, stderr = progressWriter{progress, stdout}, progressWriter{progress, stderr}
+	}
+	if logFile == nil {
+		return stdout, stderr
+	}
+	return io.MultiWriter(stdout, logFile), io.MultiWriter(stderr, logFile)
+}
+
+// stripEmoji 去掉消息开头的 emoji 及其后的空格，保留开头的换行和缩进
+func stripEmoji(message string) string {
+	trimmed := strings.TrimLeft(message, "\n ")
+	indent := message[:len(message)-len(trimmed)]
+	rest := strings.TrimLeftFunc(trimmed, func(r rune) bool {
+		return unicode.Is(unicode.So, r) || r == '\uFE0F' || r == 'ℹ'
+	})
+	if rest == trimmed {
+		return message
+	}
+	return indent + strings.TrimLeft(rest, " ")
+}
@@ -0,0 +1,598 @@
+package cmd
+
+// messagesEn 英文消息目录
+var messagesEn = map[string]string{
+	"add.already_in_manifest":    "Executable %s is already listed in manifest.json",
+	"add.create_cmd_dir_failed":  "failed to create cmd directory: %w",
+	"add.done":                   "✅ Executable '%s' added!",
+	"add.failed":                 "Failed to add executable: %v",
+	"add.manifest_updated":       "Updated manifest.json with executable: %s",
+	"add.name_required":          "Error: an executable name is required",
+	"add.next_build":             "  2. Run 'dscli build' to build all executables automatically",
+	"add.next_build_explicit":    "  3. Run 'dscli build' to build all executables",
+	"add.next_config":            "  2. Add {\"name\": \"%s\", \"source\": \"./cmd/%s\"} to executables in .dscli.json",
+	"add.next_edit":              "  1. Edit cmd/%s/main.go to implement your logic",
+	"add.source_exists":          "Source file already exists: %s",
+	"add.template_created":       "Created template file: %s",
+	"add.update_manifest_failed": "Failed to update manifest.json: %v",
+
+	"archive.file_missing":          "%s does not contain %s",
+	"archive.not_gzip":              "%s is not a valid gzip file: %w",
+	"archive.parse_manifest_failed": "failed to parse manifest.json in %s: %w",
+	"archive.read_entry_failed":     "%s: failed to read %s: %w",
+	"archive.read_failed":           "failed to read %s: %w",
+
+	"audit.clean":              "  ✅ No known vulnerabilities",
+	"audit.db_not_dir":         "vulnerability database %s is not a directory",
+	"audit.db_unavailable":     "cannot access vulnerability database %s: %w",
+	"audit.done":               "\nAudit finished: %d targets, %d vulnerabilities found",
+	"audit.entry_error":        "%s: %s: %w",
+	"audit.failed":             "Audit failed: %v",
+	"audit.finding":            "  ❌ %s [%s] %s@%s (%s)",
+	"audit.fixed_in":           "fixed in %s",
+	"audit.invalid_go_version": "invalid Go version: %q",
+	"audit.invalid_severity":   "invalid severity %q, valid values: any, low, moderate, high, critical",
+	"audit.no_buildinfo":       "%s contains no executables with readable build information",
+	"audit.no_db":              "no vulnerability database configured, use --db or set audit.db in .dscli.json",
+	"audit.no_fix":             "no fixed version",
+	"audit.no_packages":        "No module packages in output directory %s, run dscli build first or specify the files to audit",
+	"audit.parse_sbom_failed":  "failed to parse the SBOM of %s: %w",
+	"audit.read_db_failed":     "failed to read the vulnerability database: %w",
+	"audit.target":             "🔍 %s (%d modules)",
+	"audit.threshold_reached":  "❌ Found vulnerabilities with severity %s or higher",
+
+	"build.add_bin_dir_failed":         "⚠️  Failed to add bin directory: %v",
+	"build.add_binary_failed":          "⚠️  Failed to add binary %s: %v",
+	"build.add_dir_failed":             "⚠️  Failed to add directory %s: %v",
+	"build.add_file_failed":            "⚠️  Failed to add file %s: %v",
+	"build.all_target_unsupported":     "⚠️  %s is not supported by the installed toolchain, skipping",
+	"build.asset_excluded":             "ℹ️  Skipping excluded asset: %s",
+	"build.asset_missing":              "⚠️  Asset not found: %s",
+	"build.asset_object_invalid":       "asset objects must have source and output fields",
+	"build.asset_unsupported":          "unsupported asset format",
+	"build.assets_not_array":           "assets must be an array",
+	"build.audit_error":                "audit failed: %w",
+	"build.audit_failed":               "audit failed: found vulnerabilities with severity %s or higher",
+	"build.auditing":                   "Auditing dependencies for known vulnerabilities...",
+	"build.cache_key_failed":           "⚠️  Failed to compute cache key, building without cache: %v",
+	"build.cache_store_failed":         "⚠️  Failed to save %s to cache: %v",
+	"build.cache_unavailable":          "⚠️  Build cache unavailable: %v",
+	"build.cached_executable":          "♻️  Using cached build: %s (%s)",
+	"build.cached_package":             "♻️  Using cached package: %s",
+	"build.checksums_failed":           "failed to write checksums: %w",
+	"build.config_warning":             "Warning: failed to load build config: %v",
+	"build.create_package_failed":      "failed to create package: %w",
+	"build.done":                       "✅ Build complete!",
+	"build.executable_built":           "✅ Built: %s (%s)",
+	"build.executable_failed":          "❌ Failed to build %s: %v",
+	"build.executables_failed":         "failed to build executables: %s",
+	"build.failed":                     "Build failed: %v",
+	"build.file_excluded":              "ℹ️  Skipping excluded file: %s",
+	"build.git_version":                "Using git version: %s",
+	"build.git_version_failed":         "failed to get version from git: %w",
+	"build.invalid_target":             "invalid target %s, expected os/arch, all or a wildcard such as linux/*",
+	"build.no_executables":             "no executables found (a main package is required in the project root or cmd/<name>)",
+	"build.no_targets_selected":        "-t %s excludes every target platform",
+	"build.nothing_built":              "no executables were built successfully",
+	"build.output_dir_failed":          "failed to create output directory: %w",
+	"build.parse_assets_failed":        "⚠️  Failed to parse assets config: %v",
+	"build.parse_config_failed":        "failed to parse config file: %w",
+	"build.project":                    "Building project: %s",
+	"build.remove_stale_failed":        "failed to remove previous build artifacts: %w",
+	"build.sbom_failed":                "⚠️  Failed to generate SBOM: %v",
+	"build.sbom_written":               "✅ SBOM written: %s",
+	"build.sign_failed":                "signing failed: %w",
+	"build.some_targets_failed":        "%d of %d targets failed to build",
+	"build.stages_header":              "Pre-build stages:",
+	"build.summary":                    "Build summary:",
+	"build.target":                     "Building for %s/%s...",
+	"build.target_failed":              "Warning: build for %s/%s failed: %v",
+	"build.target_list_unavailable":    "⚠️  %v, using the built-in target list",
+	"build.targets_failed":             "failed to determine build targets: %w",
+	"build.unsupported_target":         "unsupported target platform %s (see go tool dist list)",
+	"build.unsupported_target_suggest": "unsupported target platform %s, did you mean %s? (see go tool dist list)",
+	"build.update_manifest_failed":     "failed to update manifest: %w",
+
+	"bump.dirty_worktree":       "the working tree has uncommitted changes: %s; commit or stash them before using --tag",
+	"bump.done":                 "\n✅ Version updated to %s",
+	"bump.failed":               "Failed to bump the version: %v",
+	"bump.invalid_version":      "invalid version in manifest.json: %w",
+	"bump.not_higher":           "the new version %s must be higher than the current version %s",
+	"bump.source_updated":       "Updated source: %s",
+	"bump.tag_exists":           "tag %s already exists",
+	"bump.tagged":               "Created git tag: %s",
+	"bump.update_source_failed": "failed to update the version in source: %w",
+	"bump.version":              "Version: %s → %s",
+
+	"cache.clean_dry_run":      "Would remove %d cache entries, freeing %s",
+	"cache.clean_failed":       "Failed to clean the cache: %v",
+	"cache.cleaned":            "✅ Removed %d cache entries, freed %s",
+	"cache.dir":                "Cache directory: %s",
+	"cache.dir_failed":         "Failed to locate the cache directory: %v",
+	"cache.executables":        "Executables: %d (%s)",
+	"cache.go_version_failed":  "failed to get the Go version: %w",
+	"cache.hash_source_failed": "failed to hash the source tree: %w",
+	"cache.packages":           "Packages: %d (%s)",
+	"cache.total":              "Total: %s",
+
+	"changelog.failed":             "Failed to generate the CHANGELOG: %v",
+	"changelog.manifest_updated":   "Wrote the release notes to the release_notes field of manifest.json",
+	"changelog.no_version":         "manifest.json has no version field",
+	"changelog.notes_written":      "Wrote RELEASE_NOTES.md (add it to assets in .dscli.json to package it)",
+	"changelog.updated":            "Updated %s (%d versions)",
+	"changelog.write_failed":       "failed to write %s: %w",
+	"changelog.write_notes_failed": "failed to write RELEASE_NOTES.md: %w",
+
+	"checksum.hash_failed": "failed to compute the checksum of %s: %w",
+	"checksum.mismatch":    "checksum mismatch for %s: expected %s, got %s",
+
+	"clean.dry_run":       "Would remove %s (%s)",
+	"clean.has_manifest":  "the directory contains manifest.json and may be a module project",
+	"clean.is_root":       "the output directory cannot be the project root",
+	"clean.missing":       "Output directory %s does not exist, nothing to clean",
+	"clean.outside":       "the output directory is outside the project directory",
+	"clean.refused":       "❌ Refusing to clean %s: %v",
+	"clean.rel_failed":    "cannot determine the relation to the project directory: %w",
+	"clean.remove_failed": "Failed to remove the output directory: %v",
+	"clean.removed":       "✅ Removed output directory %s",
+
+	"common.create_dir_failed":       "failed to create directory %s: %w",
+	"common.create_file_failed":      "failed to create file %s: %w",
+	"common.error":                   "Error: %v",
+	"common.error_not_project":       "Error: not in a valid dsserv project directory (manifest.json not found)",
+	"common.load_config_failed":      "Failed to load the build configuration: %v",
+	"common.marshal_manifest_failed": "failed to encode manifest.json: %w",
+	"common.next_steps":              "Next steps:",
+	"common.not_project":             "not in a valid dsserv project directory (manifest.json not found)",
+	"common.parse_manifest_failed":   "failed to parse manifest.json: %w",
+	"common.read_manifest_failed":    "failed to read manifest.json: %w",
+	"common.write_manifest_failed":   "failed to write manifest.json: %w",
+
+	"compare.buildinfo":    "\nBuild information (%s):",
+	"compare.digest":       "digest %s → %s",
+	"compare.failed":       "Comparison failed: %v",
+	"compare.files":        "\nFiles (%d unchanged):",
+	"compare.go_version":   "  Go version: %s → %s",
+	"compare.header":       "Comparing %s ↔ %s",
+	"compare.main_version": "  Main module version: %s → %s",
+	"compare.mode":         "mode %s → %s",
+	"compare.modules":      "  Dependencies:",
+	"compare.no_diff":      "  No differences",
+	"compare.settings":     "  Build settings:",
+	"compare.size":         "size %s → %s",
+
+	"create.check_dir_failed":    "failed to check project directory: %w",
+	"create.default_description": "A dsserv module",
+	"create.dir_not_empty":       "directory %s exists and is not empty; use --force to overwrite existing files or --merge to only write missing files",
+	"create.done":                "✅ Project '%s' created!",
+	"create.failed":              "Failed to create project: %v",
+	"create.force_merge":         "Error: --force and --merge cannot be used together",
+	"create.git_failed":          "⚠️  Failed to initialise git repository: %v",
+	"create.go_version_invalid":  "invalid Go version %q: expected 1.N or 1.N.P",
+	"create.mkdir_failed":        "failed to create project directory: %w",
+	"create.module_char":         "invalid module path %q: contains invalid character %q",
+	"create.module_empty":        "module path must not be empty",
+	"create.module_relative":     "invalid module path %q: contains empty or relative path elements",
+	"create.module_slash":        "invalid module path %q: must not start or end with /",
+	"create.name_required":       "Error: a project name is required in non-interactive mode",
+	"create.plan.create":         "new",
+	"create.plan.overwrite":      "overwrite",
+	"create.plan.skip":           "skip",
+	"create.plan_header":         "The following files would be generated in %s (dry-run, nothing written):\n",
+	"create.prompt.author":       "Author:",
+	"create.prompt.description":  "Description:",
+	"create.prompt.name":         "Project name:",
+	"create.prompt.version":      "Version:",
+	"create.prompt_failed":       "Failed to get project information: %v",
+	"create.skip_existing":       "Skipping existing file: %s",
+	"create.tidy_failed":         "⚠️  go mod tidy failed: %v",
+	"create.tidying":             "Running go mod tidy...",
+
+	"cvss.invalid":        "invalid CVSS vector: %q",
+	"cvss.invalid_v3":     "invalid CVSS v3 vector: %q",
+	"cvss.missing_metric": "CVSS vector %q has no valid %s metric",
+	"cvss.missing_scope":  "CVSS vector %q has no valid S metric",
+
+	"delta.copy_out_of_range":   "corrupt delta data: copy range exceeds the old file",
+	"delta.corrupt":             "corrupt delta data: %w",
+	"delta.insert_out_of_range": "corrupt delta data: insert length out of range",
+	"delta.unknown_op":          "corrupt delta data: unknown operation %q",
+
+	"doctor.asset_missing":           "asset not found: %s",
+	"doctor.cache_unset":             "not set or disabled",
+	"doctor.cgo_disabled_race":       "the test stage enables the race detector but CGO_ENABLED is off, so tests run without -race",
+	"doctor.cgo_no_cc":               "CGO_ENABLED=1 but the C compiler %s was not found",
+	"doctor.cgo_ok":                  "CGO_ENABLED=%s, cross-compilation uses CGO_ENABLED=0",
+	"doctor.config_default":          "not present, using defaults",
+	"doctor.config_ok":               "valid",
+	"doctor.executables":             "executables",
+	"doctor.exists":                  "present",
+	"doctor.fix":                     "Fix: %s",
+	"doctor.fix.asset_missing":       "create the file or directory, or remove it from assets in .dscli.json",
+	"doctor.fix.cache_unwritable":    "fix the directory permissions, or use go env -w %s=<writable dir> to choose another directory",
+	"doctor.fix.cgo_disabled_race":   "set CGO_ENABLED=1 and install a C compiler, or set \"race\": false for the test stage",
+	"doctor.fix.cgo_no_cc":           "install a C compiler (such as gcc or clang) or set CGO_ENABLED=0; dscli always cross-compiles with CGO_ENABLED=0",
+	"doctor.fix.config":              "fix the field in .dscli.json, see the build configuration section of the README",
+	"doctor.fix.dir_unwritable":      "fix the permissions or owner of %s",
+	"doctor.fix.executables":         "create a main package in the project root or cmd/<name> (see dscli add), or fix executables in .dscli.json",
+	"doctor.fix.go_broken":           "reinstall Go and check that GOROOT points at the right installation",
+	"doctor.fix.go_missing":          "install Go from https://go.dev/dl/ and add its bin directory to PATH",
+	"doctor.fix.go_version_download": "in offline environments upgrade Go to %s or later",
+	"doctor.fix.go_version_local":    "upgrade Go to %s or later, or unset GOTOOLCHAIN=local",
+	"doctor.fix.goflags":             "make sure these flags work for cross-compilation, or clear them with go env -u GOFLAGS",
+	"doctor.fix.goflags_vendor":      "run go mod vendor, or remove -mod=vendor from GOFLAGS",
+	"doctor.fix.gomod_missing":       "run go mod init <module path> and go mod tidy",
+	"doctor.fix.manifest":            "fix manifest.json, or compare it with the template generated by dscli create",
+	"doctor.fix.not_project":         "run dscli doctor in the project root, or create a project with dscli create / dscli init",
+	"doctor.fix.output_dir_unsafe":   "set output_dir in .dscli.json to a subdirectory of the project such as dist",
+	"doctor.fix.targets":             "upgrade the Go toolchain, or use -t to build only supported targets",
+	"doctor.go":                      "go command",
+	"doctor.go_broken":               "%s does not run: %v",
+	"doctor.go_missing":              "go was not found on PATH",
+	"doctor.go_version":              "Go version",
+	"doctor.go_version_download":     "%s is older than %s required by go.mod; a newer toolchain will be downloaded at build time",
+	"doctor.go_version_ok":           "%s, go.mod requires %s",
+	"doctor.go_version_old":          "%s is older than %s required by go.mod and GOTOOLCHAIN=local prevents downloading a newer toolchain",
+	"doctor.goflags_empty":           "not set",
+	"doctor.goflags_ldflags":         "-ldflags in GOFLAGS=%q is overridden by the -ldflags dscli passes, so only the version and build date are linked in",
+	"doctor.goflags_race":            "GOFLAGS=%q contains -race, which fails because cross-compilation uses CGO_ENABLED=0",
+	"doctor.goflags_set":             "-buildmode in GOFLAGS=%q applies to every go build run by dscli",
+	"doctor.goflags_vendor":          "GOFLAGS=%q uses vendor mode but the project has no vendor directory",
+	"doctor.gomod_missing":           "go.mod not found",
+	"doctor.host_target":             "host platform",
+	"doctor.manifest_invalid":        "cannot be read: %v",
+	"doctor.manifest_missing_field":  "missing the %s field",
+	"doctor.not_dir":                 "%s is not a directory",
+	"doctor.not_project":             "the current directory is not a dsserv project (manifest.json not found), skipping project checks",
+	"doctor.not_writable":            "%s is not writable: %v",
+	"doctor.output_dir_unsafe":       "dscli clean will refuse to remove this directory: %v",
+	"doctor.project":                 "project directory",
+	"doctor.section.permissions":     "Write permissions",
+	"doctor.section.project":         "Project",
+	"doctor.section.targets":         "Target platforms",
+	"doctor.section.toolchain":       "Go toolchain",
+	"doctor.summary":                 "Checks finished: %d passed, %d warnings, %d failed",
+	"doctor.target_unsupported":      "not supported by the installed toolchain: %s",
+	"doctor.targets_all_ok":          "all %d targets are supported",
+	"doctor.targets_count":           "the installed toolchain supports %d targets",
+	"doctor.writable":                "writable",
+
+	"executable.collision":       "executable name collision: %s and %s would both produce bin/%s; remove one or list executables explicitly in .dscli.json",
+	"executable.invalid_source":  "invalid source directory for executable %s: %w",
+	"executable.missing_fields":  "every entry in executables must have name and source fields",
+	"executable.not_main":        "source directory %[2]s of executable %[1]s is not a main package",
+	"executable.read_cmd_failed": "failed to read cmd directory: %w",
+
+	"git.already_repo":   "ℹ️  %s is already a git repository, skipping initialisation",
+	"git.initialised":    "Initialised a git repository and created the initial commit (author: %s <%s>)",
+	"git.invalid_author": "invalid git author %q, expected \"Name <email>\"",
+	"git.no_author":      "git user is not configured, specify it with --git-author \"Name <email>\": %w",
+
+	"help.aliases":      "Aliases:",
+	"help.commands":     "Available Commands:",
+	"help.examples":     "Examples:",
+	"help.flags":        "Flags:",
+	"help.global_flags": "Global Flags:",
+	"help.more":         "Use \"%s [command] --help\" for more information about a command.",
+	"help.topics":       "Additional help topics:",
+	"help.usage":        "Usage:",
+
+	"hooks.command_required": "hook object must contain a command field",
+	"hooks.failed":           "hook %s failed: %w",
+	"hooks.failed_ignored":   "⚠️  Hook %s failed, ignored: %v",
+	"hooks.invalid":          "hooks must be a string or an array",
+	"hooks.parse_failed":     "failed to parse %s hooks: %w",
+	"hooks.running":          "🔗 Running hook %s: %s",
+	"hooks.timeout":          "did not finish within %d seconds",
+	"hooks.unsupported":      "unsupported hook format",
+
+	"init.getwd_failed": "Failed to get current directory: %v",
+
+	"install.absolute_path":           "the package contains the absolute path %s",
+	"install.already_installed":       "%s@%s is already installed, use --force to reinstall",
+	"install.artifact_not_found":      "the module repository has no package of %s@%s for %s",
+	"install.current_removed":         "⚠️  Removed the current link, module %s has no active version",
+	"install.done":                    "\n✅ Installed %s@%s to %s",
+	"install.entry_error":             "%s in the package: %w",
+	"install.failed":                  "Installation failed: %v",
+	"install.illegal_path":            "the package contains the illegal path %s",
+	"install.index_checksum_mismatch": "checksum of %s does not match the index: expected %s, got %s",
+	"install.insecure":                "⚠️  No trusted public keys configured, signature check skipped by --insecure",
+	"install.invalid_version":         "invalid version %q in the package",
+	"install.module_not_found":        "module %s is not in the module repository",
+	"install.module_not_installed":    "module %s is not installed",
+	"install.no_checksum":             "⚠️  No checksum found, skipping the checksum check",
+	"install.no_lower_version":        "no installed version lower than the current version %s",
+	"install.no_platform_version":     "module %s has no version for %s",
+	"install.no_trusted_keys":         "no trusted public keys configured to verify the package signature, specify them with --trusted or signing.trusted_keys, or use --insecure to skip the signature check",
+	"install.parse_index_failed":      "failed to parse the module repository index: %w",
+	"install.platform_mismatch":       "package platform %s/%s does not match this machine (%s/%s)",
+	"install.read_index_failed":       "failed to read the module repository index: %w",
+	"install.repo_required":           "installing %s requires a local module repository given with --repo",
+	"install.resolved":                "Resolved %s@%s from the module repository: %s",
+	"install.rollback_failed":         "Rollback failed: %v",
+	"install.rolled_back":             "✅ Rolled %s back from %s to %s",
+	"install.switch_failed":           "failed to switch the current link: %w",
+	"install.symlink_absolute":        "symlink %s in the package points to the absolute path %s",
+	"install.symlink_escapes":         "symlink %s in the package points outside the package",
+	"install.symlink_failed":          "failed to create the symlink: %w",
+	"install.symlink_unresolved":      "symlink %s in the package cannot be resolved: %w",
+	"install.through_symlink":         "would be written through the symlink %s",
+	"install.uninstall_failed":        "Uninstall failed: %v",
+	"install.uninstalled":             "✅ Uninstalled %s@%s",
+	"install.uninstalled_all":         "✅ Uninstalled all versions of module %s",
+	"install.unsupported_type":        "%s in the package has an unsupported type (%c)",
+	"install.version_in_use":          "%s@%s is the version in use, roll back first or use --force",
+	"install.version_not_installed":   "%s@%s is not installed",
+
+	"log.cache_key":     "Cache key %s: %s",
+	"log.exec":          "Running: %s",
+	"log.hook_env":      "Hook environment: %s",
+	"log.open_failed":   "failed to open log file: %w",
+	"log.verbose_quiet": "-v and -q cannot be used together",
+
+	"name.dotdot":          "must not contain \"..\"",
+	"name.empty":           "%s must not be empty",
+	"name.invalid":         "invalid %s %q: %s",
+	"name.invalid_chars":   "may only contain letters, digits, '.', '_' and '-', and must start with a letter or digit",
+	"name.invalid_suggest": "invalid %s %q: %s, try %q",
+	"name.kind.executable": "executable name",
+	"name.kind.module":     "module name",
+	"name.kind.project":    "project name",
+	"name.outside_dir":     "path %s is not inside %s",
+	"name.reserved":        "is a reserved name on Windows",
+	"name.too_long":        "must not be longer than %d characters",
+	"name.trailing_dot":    "must not end with '.'",
+
+	"patch.apply_failed":         "Failed to apply the delta package: %v",
+	"patch.created":              "\n✅ Created delta package %s (%.2f MB, full package %.2f MB)",
+	"patch.diff_failed":          "Failed to create the delta package: %v",
+	"patch.digest_matched":       "Package digest: matches %s@%s (%s)",
+	"patch.digest_mismatch":      "rebuilt package digest %s does not match the digest of %s@%s (%s)",
+	"patch.file_digest_mismatch": "digest of the rebuilt %s does not match",
+	"patch.header_mismatch":      "tar header name %[2]s of %[1]s in the delta package does not match its path",
+	"patch.invalid_action":       "invalid change type %[2]q for %[1]s",
+	"patch.mismatch":             "deltas can only be created between packages of the same module and platform: %s %s/%s and %s %s/%s",
+	"patch.missing_delta":        "the delta package has no deltas/%s.delta",
+	"patch.missing_file":         "the delta package has no files/%s",
+	"patch.missing_old":          "the old package has no %s",
+	"patch.no_header":            "%s has no tar header",
+	"patch.old_mismatch":         "the old package does not match the delta package: expected %s@%s (%s), got digest %s",
+	"patch.old_mismatch_forced":  "⚠️  The old package digest does not match the delta package, applying anyway",
+	"patch.parse_meta_failed":    "failed to parse %s: %w",
+	"patch.rebuilt":              "\n✅ Rebuilt %s@%s: %s",
+	"patch.summary":              "File changes: %d unchanged, %d added, %d diffed, %d replaced, %d removed",
+	"patch.unclean_path":         "path %s in the delta package is not canonical",
+	"patch.unsupported_format":   "unsupported delta package format: %s",
+	"patch.verified":             "Verified the digests of %d files",
+
+	"progress.cached":    "done %s (cached)",
+	"progress.compiling": "compiling %s (%d/%d)",
+	"progress.done":      "done %s",
+	"progress.failed":    "failed: %s",
+	"progress.header":    "Building %d/%d targets (%s)",
+	"progress.packaging": "packaging",
+	"progress.queued":    "queued",
+
+	"publish.auth_failed":         "%w: authentication failed (%s)",
+	"publish.content_differs":     "the published file has different content",
+	"publish.done":                "\nPublishing finished: %d files uploaded, %d already published files skipped",
+	"publish.dry_run":             "Would upload the following files (dry-run):",
+	"publish.failed":              "Publishing failed: %v",
+	"publish.head_failed":         "failed to query %s: %s",
+	"publish.manifest_incomplete": "manifest.json of %s has no name or version",
+	"publish.no_packages":         "no module packages to publish in %s, run dscli build first",
+	"publish.no_token":            "⚠️  Environment variable %s is not set, uploading without an access token",
+	"publish.no_url":              "no module repository URL configured, use --url or publish.url in .dscli.json",
+	"publish.rejected":            "upload rejected by the server",
+	"publish.resume":              "ℹ️  Resuming upload of %[2]s at byte %[1]d",
+	"publish.retry":               "⚠️  %v, retrying in %s (%d/%d)",
+	"publish.server_error":        "server returned %s: %s",
+	"publish.skipped":             "ℹ️  Already published, skipped: %s",
+	"publish.upload_failed":       "failed to upload %s: %w",
+	"publish.uploaded":            "✅ Uploaded: %s",
+
+	"repo.duplicate":           "⚠️  Duplicate %s@%s (%s): keeping %s, ignoring %s",
+	"repo.index_failed":        "Failed to generate the index: %v",
+	"repo.index_written":       "✅ Generated %s (%d modules, %d packages)",
+	"repo.invalid_package":     "⚠️  Skipping invalid module package %s: %v",
+	"repo.manifest_incomplete": "⚠️  Skipping %s: manifest.json has no name, version, os or arch",
+	"repo.write_failed":        "Failed to write %s: %v",
+
+	"root.invalid_output":   "invalid output format %q, valid values: text, json, ndjson",
+	"root.unknown_command":  "Unknown command \"%s\"",
+	"root.unsupported_lang": "unsupported language %q, valid values: zh-CN, en",
+
+	"sbom.buildinfo_failed": "failed to read the build information of %s: %w",
+
+	"scaffold.exe.running":         "%s is running",
+	"scaffold.exe.starting":        "Starting %s...",
+	"scaffold.exe.todo":            "implement your %s logic here",
+	"scaffold.main.cleanup":        "Cleanup logic goes here",
+	"scaffold.main.config_warning": "Warning: failed to read config file: %v",
+	"scaffold.main.load_config":    "Load configuration",
+	"scaffold.main.logic":          "Write your main service logic here",
+	"scaffold.main.loop":           "Main service loop",
+	"scaffold.main.running":        "Service is running...",
+	"scaffold.main.setup_shutdown": "Set up graceful shutdown",
+	"scaffold.main.shutting_down":  "Shutting down gracefully...",
+	"scaffold.main.starting":       "Starting %s v%%s (built: %%s)",
+	"scaffold.main.stopped":        "Service stopped.",
+	"scaffold.main.wait_signal":    "Wait for a shutdown signal",
+
+	"semver.invalid":      "invalid semantic version: %q",
+	"semver.unknown_part": "unknown version part: %s",
+
+	"sign.checksum_matched":        "Checksum: matches %s",
+	"sign.file_not_pem":            "%s is not in PEM format",
+	"sign.find_failed":             "Failed to find files to sign: %v",
+	"sign.key_exists":              "%s already exists, use --force to overwrite",
+	"sign.keygen_failed":           "Failed to generate the key pair: %v",
+	"sign.load_key_failed":         "failed to load private key %s: %w",
+	"sign.no_key":                  "no signing key specified, use --key, signing.key or DSCLI_SIGNING_KEY",
+	"sign.no_trusted_keys":         "no trusted public keys, specify them with --trusted or signing.trusted_keys",
+	"sign.not_ed25519_private":     "not an ed25519 private key",
+	"sign.not_ed25519_public":      "%s is not an ed25519 public key",
+	"sign.not_pem":                 "not in PEM format",
+	"sign.nothing":                 "No files to sign",
+	"sign.parse_public_key_failed": "failed to parse public key %s: %w",
+	"sign.private_key":             "Private key: %s",
+	"sign.public_key":              "Public key: %s (fingerprint %s)",
+	"sign.read_signature_failed":   "failed to read the signature file: %w",
+	"sign.read_trusted_failed":     "failed to read trusted public keys: %w",
+	"sign.sign_failed":             "Signing failed: %v",
+	"sign.signature_malformed":     "signature file %s is malformed",
+	"sign.signature_untrusted":     "signature is invalid or not made by a trusted public key",
+	"sign.signature_valid":         "Signature: %s signature is valid",
+	"sign.signed":                  "Signed: %s",
+	"sign.signed_by":               "Signature: signed by public key %s",
+	"sign.verified":                "✅ %s verified",
+	"sign.verify_failed":           "❌ Verification failed: %v",
+
+	"stages.args_invalid":      "args of stage %s must be an array of strings",
+	"stages.done":              "✅ Stage %s finished (%s)",
+	"stages.failed":            "stage %s failed: %w",
+	"stages.failed_ignored":    "⚠️  Stage %s failed, ignored: %v",
+	"stages.name_required":     "stage object must contain a name field",
+	"stages.not_array":         "stages must be an array",
+	"stages.profile_none":      "profile %s does not exist, no profiles are defined in .dscli.json",
+	"stages.profile_not_found": "profile %s does not exist, available profiles: %s",
+	"stages.race_needs_cgo":    "⚠️  The race detector requires cgo but CGO_ENABLED=0, running tests without -race",
+	"stages.race_unsupported":  "⚠️  %s does not support the race detector, running tests without -race",
+	"stages.running":           "▶ Running stage %s: %s",
+	"stages.unknown":           "unknown build stage %q, valid values: generate, vet, test",
+	"stages.unsupported":       "unsupported stage format",
+
+	"toolchain.dist_list_failed": "failed to list targets supported by the toolchain (go tool dist list): %w",
+
+	"cmd.root.short":         "dscli is a scaffolding tool for developing dsserv modules",
+	"cmd.root.long":          "dscli is a CLI tool for creating and building dsserv modules.\nIt provides vue-cli style project scaffolding and build commands.",
+	"cmd.doctor.short":       "Check the build environment and suggest fixes",
+	"cmd.doctor.long":        "Check the Go toolchain, project structure, configuration, write permissions and target support,\nand suggest fixes for the problems found. Exits with a non-zero status when a check fails.\n\nOutside a project directory only the toolchain is checked.",
+	"cmd.help.short":         "Help about any command",
+	"cmd.help.long":          "Help about any command.",
+	"cmd.version.short":      "Print the version number of dscli",
+	"cmd.version.long":       "Print the version number and other build information of dscli.",
+	"cmd.create.short":       "Create a new dsserv module project",
+	"cmd.create.long":        "Create a new dsserv module project with the given name.\nThis command creates a new directory containing the project structure and files.",
+	"cmd.init.short":         "Initialise a dsserv module project in the current directory",
+	"cmd.init.long":          "Generate the scaffolding files of a dsserv module project in the current directory.\nThe project name defaults to the name of the current directory. --force or --merge is required when the directory is not empty.",
+	"cmd.add.short":          "Add a new executable to the project",
+	"cmd.add.long":           "Add a new executable to an existing dsserv project.\nThis command creates a new subdirectory with a main.go template under cmd.\nAll subdirectories of cmd are discovered and built automatically.",
+	"cmd.build.short":        "Build the dsserv module for multiple platforms",
+	"cmd.build.long":         "Build the dsserv module for multiple platforms and architectures.\nThis command cross-compiles the project for the selected Windows, macOS and Linux architectures,\nupdates the build information in manifest.json and creates a tar.gz package per platform.",
+	"cmd.audit.short":        "Audit the Go dependencies of modules against a local vulnerability database",
+	"cmd.audit.long":         "Read the SBOM in module packages (or the Go build information embedded in executables when there is none),\ncompare the Go standard library and dependency versions against a local OSV vulnerability database,\nand report affected modules and fixed versions.\n\nThe audit runs fully offline. The database directory is set with --db or audit.db in .dscli.json;\neach .json file in it (subdirectories allowed) is one OSV record.\nWithout arguments, all packages in the output directory are audited.\nExits with a non-zero status when a vulnerability reaches the --fail-on severity.",
+	"cmd.bump.short":         "Bump the semantic version of the module",
+	"cmd.bump.long":          "Bump the module version in manifest.json following semantic versioning rules.\nThe argument is major, minor, patch, prerelease or an explicit version (such as 1.4.0).\nOptionally updates the version variable in Go source, commits the change and creates a git tag.",
+	"cmd.cache.short":        "Manage the build cache",
+	"cmd.cache.long":         "Manage the build cache used by dscli build.\n\nThe cache lives in the dscli directory under the user cache directory (override with DSCLI_CACHE_DIR)\nand is content-addressed: targets whose sources, go.mod/go.sum, build flags, platform and assets are unchanged\nreuse the binaries and packages from the previous build.",
+	"cmd.cache.info.short":   "Show the location and size of the build cache",
+	"cmd.cache.clean.short":  "Clean the build cache",
+	"cmd.cache.clean.long":   "Remove all entries from the build cache, or with --older-than only entries unused for longer than the given duration.",
+	"cmd.changelog.short":    "Generate CHANGELOG.md from git history",
+	"cmd.changelog.long":     "Read the commits between tags in the local git repository, group them by Conventional Commits type\nand generate or update CHANGELOG.md. By default only the entry for the current version in manifest.json is generated;\nuse --all to regenerate the complete CHANGELOG from all version tags.",
+	"cmd.clean.short":        "Remove the build output directory",
+	"cmd.clean.long":         "Remove the build output directory set by output_dir in .dscli.json (dist by default).\n\nTo prevent accidents the output directory must be inside the project, must not be the project root\nand must not contain manifest.json. Use --cache to also empty the build cache.",
+	"cmd.compare.short":      "Compare the contents of two module packages",
+	"cmd.compare.long":       "Compare the file lists, sizes, modes, digests and manifest.json fields of two module packages,\nas well as the Go build information embedded in each executable (Go version, dependency versions and build settings).",
+	"cmd.install.short":      "Install a module package into the Agent modules directory",
+	"cmd.install.long":       "Verify the checksum and signature of a module package, check that its os/arch matches this machine,\nextract it safely to <modules-dir>/<name>/<version> and atomically switch the current symlink.\nThe argument is a local .tar.gz package or name@version (version may be latest);\nthe latter is resolved from index.json in the local module repository given by --repo.\nThe package must be signed by a key from --trusted or signing.trusted_keys; use --insecure to install a package without signature verification.",
+	"cmd.uninstall.short":    "Uninstall a module from the Agent modules directory",
+	"cmd.uninstall.long":     "Uninstall the given version of a module, or all versions when no version is given.\nUninstalling the version in use requires --force.",
+	"cmd.rollback.short":     "Roll a module back to a previously installed version",
+	"cmd.rollback.long":      "Switch the current symlink of a module to a lower installed version.\nBy default the highest version below the current one is used; use --to to choose the version.",
+	"cmd.diff-package.short": "Create a delta package between two module versions",
+	"cmd.diff-package.long":  "Compare two packages of the same module and platform and create a delta package containing only the changes:\nmodified executables are stored as binary diffs, new assets in full, and removed files and the new manifest are recorded.\nUse dscli apply-patch to rebuild the new package from the old one.",
+	"cmd.apply-patch.short":  "Apply a delta package to rebuild a new module package",
+	"cmd.apply-patch.long":   "Apply a delta package created by diff-package to the old package to rebuild the new one,\nverifying the digest of every rebuilt file and the SHA-256 of the whole package.",
+	"cmd.publish.short":      "Upload the module packages in the output directory to a module repository",
+	"cmd.publish.long":       "Upload every module package in the output directory, with its checksum (<file>.sha256) and signature, to a module repository via HTTP PUT.\nFiles go to <url>/<name>/<version>/<file>, with name and version taken from the manifest.json inside the package.\nThe access token is read from an environment variable (DSCLI_PUBLISH_TOKEN by default) and sent as a Bearer token.\nFiles already published with a matching checksum are skipped and partial uploads are resumed.",
+	"cmd.repo.short":         "Manage a local module repository",
+	"cmd.repo.long":          "Manage a local module repository made of static files.",
+	"cmd.repo.index.short":   "Generate index.json for a directory of module packages",
+	"cmd.repo.index.long":    "Scan a directory (including subdirectories) for dsserv module packages, read the manifest.json in each\nand generate an index.json grouped by module name, version, OS and architecture,\nso that the Agent or any static HTTP server can resolve the latest version for a platform.",
+	"cmd.keygen.short":       "Generate an ed25519 key pair for signing module packages",
+	"cmd.keygen.long":        "Generate an ed25519 key pair for signing module packages.\nThe private key is saved as <name>.key (PKCS#8 PEM, mode 0600) and the public key as <name>.pub (PKIX PEM).\nKeep the private key safe and distribute the public key to the Agents that verify packages.",
+	"cmd.sign.short":         "Create detached ed25519 signatures for module packages",
+	"cmd.sign.long":          "Sign module packages and the checksums file with a local private key, creating detached signatures (<file>.sig).\nWithout arguments, all .tar.gz packages and checksums.txt in the output directory are signed.\nThe private key is taken from --key, signing.key in .dscli.json or the DSCLI_SIGNING_KEY environment variable, in that order.",
+	"cmd.verify.short":       "Verify the signature and checksum of a module package",
+	"cmd.verify.long":        "Verify the detached signature (<package>.sig) of a module package against the list of trusted public keys,\nand its SHA-256 checksum when checksums.txt exists next to the package.\nTrusted keys come from --trusted and signing.trusted_keys in .dscli.json and may be key files or directories.",
+
+	"flag.apply-patch.force":       "Try to apply the delta even if the old package digest does not match the recorded one",
+	"flag.apply-patch.out":         "Path of the rebuilt package (defaults to the name of the new version package)",
+	"flag.audit.db":                "Local OSV vulnerability database directory (overrides audit.db in .dscli.json)",
+	"flag.audit.fail-on":           "Fail the audit at this severity: any, low, moderate, high, critical",
+	"flag.build.audit":             "Audit the generated packages against the local vulnerability database after building",
+	"flag.build.fail-on":           "Used with --audit, fail the build at this severity",
+	"flag.build.from-git":          "Derive the module version from git describe",
+	"flag.build.key":               "Path of the signing private key (used with --sign)",
+	"flag.build.no-cache":          "Do not use the build cache and recompile every target",
+	"flag.build.profile":           "Use a profile defined under profiles in .dscli.json",
+	"flag.build.sign":              "Sign the generated packages and checksums file with an ed25519 private key",
+	"flag.build.target":            "Target platforms, comma separated: os/arch, all, wildcards (such as linux/*, */arm64) or exclusions starting with ! (such as all,!windows/386)",
+	"flag.bump.preid":              "Prerelease identifier such as rc or beta (defaults to the current identifier or rc)",
+	"flag.bump.source-var":         "Name of the version variable in Go source",
+	"flag.bump.tag":                "Commit the change and create a git tag",
+	"flag.bump.tag-prefix":         "git tag prefix",
+	"flag.bump.update-source":      "Also update the version variable in Go source",
+	"flag.cache.clean.dry-run":     "Only show the number and size of entries that would be removed",
+	"flag.cache.clean.older-than":  "Only remove entries unused for longer than this duration, such as 168h",
+	"flag.changelog.all":           "Regenerate the complete CHANGELOG from all version tags",
+	"flag.changelog.file":          "Path of the CHANGELOG file",
+	"flag.changelog.manifest":      "Write the entry for the current version to release_notes in the project's own manifest.json (modifies the source file, which is then packaged on build)",
+	"flag.changelog.release-notes": "Write the entry for the current version to RELEASE_NOTES.md",
+	"flag.changelog.tag-prefix":    "Version tag prefix",
+	"flag.clean.cache":             "Also empty the build cache",
+	"flag.clean.dry-run":           "Only show what would be removed",
+	"flag.create.author":           "Project author",
+	"flag.create.description":      "Project description",
+	"flag.create.dry-run":          "Only print the file tree that would be generated, without writing any files",
+	"flag.create.force":            "Overwrite existing files when the target directory is not empty",
+	"flag.create.git":              "Initialise a git repository and create an initial commit, author taken from git config by default",
+	"flag.create.git-author":       "Author of the initial commit (format: \"Name <email>\")",
+	"flag.create.go-version":       "Go version in go.mod, defaults to the current Go toolchain version",
+	"flag.create.merge":            "Only write missing files when the target directory is not empty",
+	"flag.create.module":           "Go module path (such as github.com/org/name), defaults to the project name",
+	"flag.create.non-interactive":  "Run in non-interactive mode",
+	"flag.create.tidy":             "Run go mod tidy after creation to download dependencies and generate go.sum",
+	"flag.create.verbose":          "Print detailed output (no -v shorthand in create and init)",
+	"flag.create.version":          "Project version",
+	"flag.diff-package.out":        "Path of the delta package (defaults to <name>_<os>_<arch>_<old>_to_<new>.patch.tar.gz)",
+	"flag.init.author":             "Project author",
+	"flag.init.description":        "Project description",
+	"flag.init.dry-run":            "Only print the file tree that would be generated, without writing any files",
+	"flag.init.force":              "Overwrite existing files when the target directory is not empty",
+	"flag.init.git":                "Initialise a git repository and create an initial commit, author taken from git config by default",
+	"flag.init.git-author":         "Author of the initial commit (format: \"Name <email>\")",
+	"flag.init.go-version":         "Go version in go.mod, defaults to the current Go toolchain version",
+	"flag.init.merge":              "Only write missing files when the target directory is not empty",
+	"flag.init.module":             "Go module path (such as github.com/org/name), defaults to the project name",
+	"flag.init.non-interactive":    "Run in non-interactive mode",
+	"flag.init.tidy":               "Run go mod tidy after creation to download dependencies and generate go.sum",
+	"flag.init.verbose":            "Print detailed output (no -v shorthand in create and init)",
+	"flag.init.version":            "Project version",
+	"flag.install.dir":             "Agent modules directory (defaults to DSCLI_MODULES_DIR, modules_dir in .dscli.json or ./modules)",
+	"flag.install.force":           "Reinstall a version that is already installed",
+	"flag.install.insecure":        "Install a package without signature verification when no trusted keys are configured",
+	"flag.install.repo":            "Local module repository directory (containing index.json) used to resolve name@version",
+	"flag.install.trusted":         "Trusted public key file or directory (repeatable)",
+	"flag.keygen.force":            "Overwrite existing key files",
+	"flag.keygen.out":              "Key file name prefix, generates <name>.key and <name>.pub",
+	"flag.publish.dry-run":         "Only print the files that would be uploaded",
+	"flag.publish.retries":         "Number of retries for failed uploads (defaults to publish.retries or 3)",
+	"flag.publish.url":             "Upload URL of the module repository (overrides publish.url in .dscli.json)",
+	"flag.repo.index.out":          "Path of the index file (defaults to <dir>/index.json)",
+	"flag.rollback.dir":            "Agent modules directory (defaults to DSCLI_MODULES_DIR, modules_dir in .dscli.json or ./modules)",
+	"flag.rollback.to":             "Roll back to this version",
+	"flag.root.help":               "Show help for dscli",
+	"flag.root.lang":               "Language of messages: zh-CN or en (defaults to DSCLI_LANG or LANG)",
+	"flag.root.log-file":           "Write the full output transcript to this file, regardless of -q and -v",
+	"flag.root.output":             "Output format: text, json or ndjson (build, create, add, audit and compare emit structured events)",
+	"flag.root.quiet":              "Only print warnings and errors",
+	"flag.root.verbose":            "Print more details: -v shows the commands being run, -vv also cache keys and hook environment",
+	"flag.sign.key":                "Path of the signing private key",
+	"flag.uninstall.dir":           "Agent modules directory (defaults to DSCLI_MODULES_DIR, modules_dir in .dscli.json or ./modules)",
+	"flag.uninstall.force":         "Allow uninstalling the version in use",
+	"flag.verify.trusted":          "Trusted public key file or directory (repeatable)",
+}
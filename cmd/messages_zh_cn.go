@@ -0,0 +1,468 @@
+package cmd
+
+// messagesZhCN 简体中文消息目录，也是缺少翻译时的默认消息。
+// 命令和标志的中文说明直接写在命令定义中，不在这里重复。
+var messagesZhCN = map[string]string{
+	"add.already_in_manifest":    "可执行文件 %s 已存在于manifest.json中",
+	"add.create_cmd_dir_failed":  "创建cmd目录失败: %w",
+	"add.done":                   "✅ 可执行文件 '%s' 添加成功!",
+	"add.failed":                 "添加可执行文件时出错: %v",
+	"add.manifest_updated":       "已更新manifest.json，添加可执行文件: %s",
+	"add.name_required":          "错误: 需要可执行文件名称",
+	"add.next_build":             "  2. 运行 'dscli build' 自动构建所有可执行文件",
+	"add.next_build_explicit":    "  3. 运行 'dscli build' 构建所有可执行文件",
+	"add.next_config":            "  2. 在 .dscli.json 的 executables 中添加 {\"name\": \"%s\", \"source\": \"./cmd/%s\"}",
+	"add.next_edit":              "  1. 编辑 cmd/%s/main.go 实现您的逻辑",
+	"add.source_exists":          "源码文件已存在: %s",
+	"add.template_created":       "创建了模板文件: %s",
+	"add.update_manifest_failed": "更新manifest.json时出错: %v",
+
+	"archive.file_missing":          "%s 中没有 %s",
+	"archive.not_gzip":              "%s 不是有效的 gzip 文件: %w",
+	"archive.parse_manifest_failed": "解析 %s 中的 manifest.json 失败: %w",
+	"archive.read_entry_failed":     "读取 %s 中的 %s 失败: %w",
+	"archive.read_failed":           "读取 %s 失败: %w",
+
+	"audit.clean":              "  ✅ 未发现已知漏洞",
+	"audit.db_not_dir":         "漏洞数据库 %s 不是目录",
+	"audit.db_unavailable":     "无法访问漏洞数据库 %s: %w",
+	"audit.done":               "\n审计完成: %d 个对象，发现 %d 个漏洞",
+	"audit.entry_error":        "%s 中的 %s: %w",
+	"audit.failed":             "审计失败: %v",
+	"audit.finding":            "  ❌ %s [%s] %s@%s (%s)",
+	"audit.fixed_in":           "修复版本 %s",
+	"audit.invalid_go_version": "无效的 Go 版本: %q",
+	"audit.invalid_severity":   "无效的严重级别 %q，可选值: any、low、moderate、high、critical",
+	"audit.no_buildinfo":       "%s 中没有可读取构建信息的可执行文件",
+	"audit.no_db":              "未配置漏洞数据库，请使用 --db 或在 .dscli.json 中设置 audit.db",
+	"audit.no_fix":             "暂无修复版本",
+	"audit.no_packages":        "输出目录 %s 中没有模块包，请先运行 dscli build 或指定要审计的文件",
+	"audit.parse_sbom_failed":  "解析 %s 的 SBOM 失败: %w",
+	"audit.read_db_failed":     "读取漏洞数据库失败: %w",
+	"audit.target":             "🔍 %s (%d 个模块)",
+	"audit.threshold_reached":  "❌ 发现严重级别不低于 %s 的漏洞",
+
+	"build.add_bin_dir_failed":         "⚠️  无法创建bin目录: %v",
+	"build.add_binary_failed":          "⚠️  无法添加二进制文件 %s: %v",
+	"build.add_dir_failed":             "⚠️  无法添加目录 %s: %v",
+	"build.add_file_failed":            "⚠️  无法添加文件 %s: %v",
+	"build.all_target_unsupported":     "⚠️  已安装的工具链不支持 %s，跳过",
+	"build.asset_excluded":             "ℹ️  跳过被排除的资源: %s",
+	"build.asset_missing":              "⚠️  资源文件不存在: %s",
+	"build.asset_object_invalid":       "asset对象必须包含source和output字段",
+	"build.asset_unsupported":          "不支持的asset格式",
+	"build.assets_not_array":           "assets必须是数组格式",
+	"build.audit_error":                "审计失败: %w",
+	"build.audit_failed":               "审计未通过: 发现严重级别不低于 %s 的漏洞",
+	"build.auditing":                   "正在审计依赖漏洞...",
+	"build.cache_key_failed":           "⚠️  计算缓存键失败，不使用缓存: %v",
+	"build.cache_store_failed":         "⚠️  保存 %s 到缓存失败: %v",
+	"build.cache_unavailable":          "⚠️  无法使用构建缓存: %v",
+	"build.cached_executable":          "♻️  使用缓存: %s (%s)",
+	"build.cached_package":             "♻️  使用缓存: %s",
+	"build.checksums_failed":           "生成校验和文件失败: %w",
+	"build.config_warning":             "警告: 无法加载构建配置: %v",
+	"build.create_package_failed":      "创建包失败: %w",
+	"build.done":                       "✅ 构建完成!",
+	"build.executable_built":           "✅ 构建完成: %s (%s)",
+	"build.executable_failed":          "❌ 构建 %s 失败: %v",
+	"build.executables_failed":         "可执行文件构建失败: %s",
+	"build.failed":                     "构建失败: %v",
+	"build.file_excluded":              "ℹ️  跳过被排除的文件: %s",
+	"build.git_version":                "使用 git 版本号: %s",
+	"build.git_version_failed":         "从 git 获取版本号失败: %w",
+	"build.invalid_target":             "无效的目标格式: %s，应为 os/arch、all 或 linux/* 之类的通配符",
+	"build.no_executables":             "未找到任何可执行文件 (根目录或 cmd/<name> 下需要 main 包)",
+	"build.no_targets_selected":        "-t %s 排除了所有目标平台",
+	"build.nothing_built":              "没有成功构建任何可执行文件",
+	"build.output_dir_failed":          "创建输出目录失败: %w",
+	"build.parse_assets_failed":        "⚠️  解析assets配置失败: %v",
+	"build.parse_config_failed":        "解析配置文件失败: %w",
+	"build.project":                    "正在构建项目: %s",
+	"build.remove_stale_failed":        "删除旧的构建产物失败: %w",
+	"build.sbom_failed":                "⚠️  生成 SBOM 失败: %v",
+	"build.sbom_written":               "✅ 已生成 SBOM: %s",
+	"build.sign_failed":                "签名失败: %w",
+	"build.some_targets_failed":        "%d/%d 个目标平台构建失败",
+	"build.stages_header":              "构建前阶段:",
+	"build.summary":                    "构建摘要:",
+	"build.target":                     "正在为 %s/%s 构建...",
+	"build.target_failed":              "警告: %s/%s 构建失败: %v",
+	"build.target_list_unavailable":    "⚠️  %v，使用内置的目标平台列表",
+	"build.targets_failed":             "获取构建目标失败: %w",
+	"build.unsupported_target":         "不支持的目标平台 %s (参见 go tool dist list)",
+	"build.unsupported_target_suggest": "不支持的目标平台 %s，是否要使用 %s？(参见 go tool dist list)",
+	"build.update_manifest_failed":     "更新清单失败: %w",
+
+	"bump.dirty_worktree":       "工作区有未提交的修改: %s，请先提交或暂存后再使用 --tag",
+	"bump.done":                 "\n✅ 版本已更新为 %s",
+	"bump.failed":               "递增版本失败: %v",
+	"bump.invalid_version":      "manifest.json 中的版本号无效: %w",
+	"bump.not_higher":           "新版本 %s 必须高于当前版本 %s",
+	"bump.source_updated":       "已更新源码: %s",
+	"bump.tag_exists":           "标签 %s 已存在",
+	"bump.tagged":               "已创建 git 标签: %s",
+	"bump.update_source_failed": "更新源码版本失败: %w",
+	"bump.version":              "版本号: %s → %s",
+
+	"cache.clean_dry_run":      "将删除 %d 个缓存条目，释放 %s",
+	"cache.clean_failed":       "清理缓存失败: %v",
+	"cache.cleaned":            "✅ 已删除 %d 个缓存条目，释放 %s",
+	"cache.dir":                "缓存目录: %s",
+	"cache.dir_failed":         "获取缓存目录失败: %v",
+	"cache.executables":        "可执行文件: %d 个 (%s)",
+	"cache.go_version_failed":  "获取 Go 版本失败: %w",
+	"cache.hash_source_failed": "计算源码摘要失败: %w",
+	"cache.packages":           "模块包: %d 个 (%s)",
+	"cache.total":              "总计: %s",
+
+	"changelog.failed":             "生成 CHANGELOG 失败: %v",
+	"changelog.manifest_updated":   "已将变更记录写入 manifest.json 的 release_notes 字段",
+	"changelog.no_version":         "manifest.json 中缺少 version 字段",
+	"changelog.notes_written":      "已写入 RELEASE_NOTES.md (可添加到 .dscli.json 的 assets 中一起打包)",
+	"changelog.updated":            "已更新 %s (%d 个版本)",
+	"changelog.write_failed":       "写入 %s 失败: %w",
+	"changelog.write_notes_failed": "写入 RELEASE_NOTES.md 失败: %w",
+
+	"checksum.hash_failed": "计算 %s 的校验和失败: %w",
+	"checksum.mismatch":    "%s 的校验和不匹配: 期望 %s，实际 %s",
+
+	"clean.dry_run":       "将删除 %s (%s)",
+	"clean.has_manifest":  "目录中包含 manifest.json，可能是一个模块项目",
+	"clean.is_root":       "输出目录不能是项目根目录",
+	"clean.missing":       "输出目录 %s 不存在，无需清理",
+	"clean.outside":       "输出目录位于项目目录之外",
+	"clean.refused":       "❌ 拒绝清理 %s: %v",
+	"clean.rel_failed":    "无法确定与项目目录的关系: %w",
+	"clean.remove_failed": "删除输出目录失败: %v",
+	"clean.removed":       "✅ 已删除输出目录 %s",
+
+	"common.create_dir_failed":       "创建目录 %s 失败: %w",
+	"common.create_file_failed":      "创建文件 %s 失败: %w",
+	"common.error":                   "错误: %v",
+	"common.error_not_project":       "错误: 不在有效的 dsserv 项目目录中 (未找到 manifest.json)",
+	"common.load_config_failed":      "加载构建配置失败: %v",
+	"common.marshal_manifest_failed": "序列化manifest.json失败: %w",
+	"common.next_steps":              "下一步操作:",
+	"common.not_project":             "不在有效的 dsserv 项目目录中 (未找到 manifest.json)",
+	"common.parse_manifest_failed":   "解析manifest.json失败: %w",
+	"common.read_manifest_failed":    "读取 manifest.json 失败: %w",
+	"common.write_manifest_failed":   "写入manifest.json失败: %w",
+
+	"compare.buildinfo":    "\n构建信息 (%s):",
+	"compare.digest":       "摘要 %s → %s",
+	"compare.failed":       "比较失败: %v",
+	"compare.files":        "\n文件 (%d 个未变化):",
+	"compare.go_version":   "  Go 版本: %s → %s",
+	"compare.header":       "比较 %s ↔ %s",
+	"compare.main_version": "  主模块版本: %s → %s",
+	"compare.mode":         "权限 %s → %s",
+	"compare.modules":      "  依赖模块:",
+	"compare.no_diff":      "  无差异",
+	"compare.settings":     "  构建设置:",
+	"compare.size":         "大小 %s → %s",
+
+	"create.check_dir_failed":    "检查项目目录失败: %w",
+	"create.default_description": "一个 dsserv 模块",
+	"create.dir_not_empty":       "目录 %s 已存在且不为空，使用 --force 覆盖已存在的文件或 --merge 只写入缺失的文件",
+	"create.done":                "✅ 项目 '%s' 创建成功!",
+	"create.failed":              "创建项目时出错: %v",
+	"create.force_merge":         "错误: --force 和 --merge 不能同时使用",
+	"create.git_failed":          "⚠️  初始化 git 仓库失败: %v",
+	"create.go_version_invalid":  "无效的 Go 版本 %q: 格式应为 1.N 或 1.N.P",
+	"create.mkdir_failed":        "创建项目目录失败: %w",
+	"create.module_char":         "无效的模块路径 %q: 包含非法字符 %q",
+	"create.module_empty":        "模块路径不能为空",
+	"create.module_relative":     "无效的模块路径 %q: 包含空的或相对的路径元素",
+	"create.module_slash":        "无效的模块路径 %q: 不能以 / 开头或结尾",
+	"create.name_required":       "错误: 非交互模式下需要项目名称",
+	"create.plan.create":         "新建",
+	"create.plan.overwrite":      "覆盖",
+	"create.plan.skip":           "跳过",
+	"create.plan_header":         "将在 %s 中生成以下文件 (dry-run，未写入任何文件):\n",
+	"create.prompt.author":       "作者:",
+	"create.prompt.description":  "项目描述:",
+	"create.prompt.name":         "项目名称:",
+	"create.prompt.version":      "版本:",
+	"create.prompt_failed":       "获取项目信息时出错: %v",
+	"create.skip_existing":       "跳过已存在的文件: %s",
+	"create.tidy_failed":         "⚠️  go mod tidy 失败: %v",
+	"create.tidying":             "正在执行 go mod tidy...",
+
+	"cvss.invalid":        "无效的 CVSS 向量: %q",
+	"cvss.invalid_v3":     "无效的 CVSS v3 向量: %q",
+	"cvss.missing_metric": "CVSS 向量 %q 缺少有效的 %s 指标",
+	"cvss.missing_scope":  "CVSS 向量 %q 缺少有效的 S 指标",
+
+	"delta.copy_out_of_range":   "差分数据损坏: 复制范围超出旧文件",
+	"delta.corrupt":             "差分数据损坏: %w",
+	"delta.insert_out_of_range": "差分数据损坏: 插入长度超出范围",
+	"delta.unknown_op":          "差分数据损坏: 未知操作 %q",
+
+	"doctor.asset_missing":           "资源文件不存在: %s",
+	"doctor.cache_unset":             "未设置或已禁用",
+	"doctor.cgo_disabled_race":       "构建前阶段的测试启用了竞态检测，但 CGO_ENABLED 未启用，测试不会使用 -race",
+	"doctor.cgo_no_cc":               "CGO_ENABLED=1，但找不到 C 编译器 %s",
+	"doctor.cgo_ok":                  "CGO_ENABLED=%s，交叉编译使用 CGO_ENABLED=0",
+	"doctor.config_default":          "不存在，使用默认配置",
+	"doctor.config_ok":               "有效",
+	"doctor.executables":             "可执行文件",
+	"doctor.exists":                  "存在",
+	"doctor.fix":                     "修复: %s",
+	"doctor.fix.asset_missing":       "创建该文件或目录，或从 .dscli.json 的 assets 中删除",
+	"doctor.fix.cache_unwritable":    "修改目录权限，或使用 go env -w %s=<可写目录> 指定其他目录",
+	"doctor.fix.cgo_disabled_race":   "设置 CGO_ENABLED=1 并安装 C 编译器，或在 stages 中为 test 设置 \"race\": false",
+	"doctor.fix.cgo_no_cc":           "安装 C 编译器 (如 gcc 或 clang)，或设置 CGO_ENABLED=0；dscli 交叉编译始终使用 CGO_ENABLED=0",
+	"doctor.fix.config":              "修正 .dscli.json 中对应的字段，参考 README 中的构建配置说明",
+	"doctor.fix.dir_unwritable":      "修改 %s 的权限或所有者",
+	"doctor.fix.executables":         "在根目录或 cmd/<name> 下创建 main 包 (可使用 dscli add)，或修正 .dscli.json 的 executables",
+	"doctor.fix.go_broken":           "重新安装 Go，并检查 GOROOT 是否指向正确的安装目录",
+	"doctor.fix.go_missing":          "从 https://go.dev/dl/ 安装 Go，并将其 bin 目录加入 PATH",
+	"doctor.fix.go_version_download": "离线环境中请将 Go 升级到 %s 或更高版本",
+	"doctor.fix.go_version_local":    "将 Go 升级到 %s 或更高版本，或取消 GOTOOLCHAIN=local",
+	"doctor.fix.goflags":             "确认这些参数适用于交叉编译，或使用 go env -u GOFLAGS 清除",
+	"doctor.fix.goflags_vendor":      "运行 go mod vendor，或从 GOFLAGS 中删除 -mod=vendor",
+	"doctor.fix.gomod_missing":       "运行 go mod init <模块路径> 和 go mod tidy",
+	"doctor.fix.manifest":            "修正 manifest.json，或参考 dscli create 生成的模板",
+	"doctor.fix.not_project":         "在项目根目录中运行 dscli doctor，或使用 dscli create / dscli init 创建项目",
+	"doctor.fix.output_dir_unsafe":   "将 .dscli.json 的 output_dir 设置为项目内的子目录，如 dist",
+	"doctor.fix.targets":             "升级 Go 工具链，或使用 -t 只构建受支持的目标",
+	"doctor.go":                      "go 命令",
+	"doctor.go_broken":               "%s 无法运行: %v",
+	"doctor.go_missing":              "PATH 中找不到 go 命令",
+	"doctor.go_version":              "Go 版本",
+	"doctor.go_version_download":     "%s 低于 go.mod 要求的 %s，构建时会自动下载新的工具链",
+	"doctor.go_version_ok":           "%s，go.mod 要求 %s",
+	"doctor.go_version_old":          "%s 低于 go.mod 要求的 %s，且 GOTOOLCHAIN=local 禁止自动下载",
+	"doctor.goflags_empty":           "未设置",
+	"doctor.goflags_ldflags":         "GOFLAGS=%q 中的 -ldflags 会被 dscli 传入的 -ldflags 覆盖，版本号和构建时间之外的链接参数不会生效",
+	"doctor.goflags_race":            "GOFLAGS=%q 包含 -race，交叉编译使用 CGO_ENABLED=0，会导致构建失败",
+	"doctor.goflags_set":             "GOFLAGS=%q 中的 -buildmode 会作用于 dscli 执行的所有 go build 命令",
+	"doctor.goflags_vendor":          "GOFLAGS=%q 使用 vendor 模式，但项目中没有 vendor 目录",
+	"doctor.gomod_missing":           "未找到 go.mod",
+	"doctor.host_target":             "当前平台",
+	"doctor.manifest_invalid":        "无法读取: %v",
+	"doctor.manifest_missing_field":  "缺少 %s 字段",
+	"doctor.not_dir":                 "%s 不是目录",
+	"doctor.not_project":             "当前目录不是 dsserv 项目 (未找到 manifest.json)，跳过项目检查",
+	"doctor.not_writable":            "%s 不可写: %v",
+	"doctor.output_dir_unsafe":       "dscli clean 会拒绝清理该目录: %v",
+	"doctor.project":                 "项目目录",
+	"doctor.section.permissions":     "写入权限",
+	"doctor.section.project":         "项目",
+	"doctor.section.targets":         "目标平台",
+	"doctor.section.toolchain":       "Go 工具链",
+	"doctor.summary":                 "检查完成: %d 项通过，%d 项警告，%d 项失败",
+	"doctor.target_unsupported":      "已安装的工具链不支持: %s",
+	"doctor.targets_all_ok":          "%d 个目标平台均受支持",
+	"doctor.targets_count":           "已安装的工具链支持 %d 个目标平台",
+	"doctor.writable":                "可写",
+
+	"executable.collision":       "可执行文件名冲突: %s 和 %s 都会生成 bin/%s，请删除其中一个或在 .dscli.json 的 executables 中显式指定",
+	"executable.invalid_source":  "可执行文件 %s 的源目录无效: %w",
+	"executable.missing_fields":  "executables 中的每一项都必须包含 name 和 source 字段",
+	"executable.not_main":        "可执行文件 %s 的源目录 %s 不是 main 包",
+	"executable.read_cmd_failed": "读取cmd目录失败: %w",
+
+	"git.already_repo":   "ℹ️  %s 已经是 git 仓库，跳过初始化",
+	"git.initialised":    "已初始化 git 仓库并创建初始提交 (作者: %s <%s>)",
+	"git.invalid_author": "无效的 git 作者 %q，应为 \"Name <email>\" 格式",
+	"git.no_author":      "未配置 git 用户信息，请使用 --git-author \"Name <email>\" 指定: %w",
+
+	"help.aliases":      "别名:",
+	"help.commands":     "可用命令:",
+	"help.examples":     "示例:",
+	"help.flags":        "选项:",
+	"help.global_flags": "全局选项:",
+	"help.more":         "使用 \"%s [command] --help\" 获取命令的更多信息。",
+	"help.topics":       "其他帮助主题:",
+	"help.usage":        "用法:",
+
+	"hooks.command_required": "钩子对象必须包含command字段",
+	"hooks.failed":           "钩子 %s 失败: %w",
+	"hooks.failed_ignored":   "⚠️  钩子 %s 失败，已忽略: %v",
+	"hooks.invalid":          "钩子必须是字符串或数组格式",
+	"hooks.parse_failed":     "解析 %s 钩子失败: %w",
+	"hooks.running":          "🔗 运行钩子 %s: %s",
+	"hooks.timeout":          "超过 %d 秒未完成",
+	"hooks.unsupported":      "不支持的钩子格式",
+
+	"init.getwd_failed": "获取当前目录失败: %v",
+
+	"install.absolute_path":           "包中包含绝对路径 %s",
+	"install.already_installed":       "%s@%s 已安装，使用 --force 重新安装",
+	"install.artifact_not_found":      "模块仓库中没有 %s@%s 的 %s 包",
+	"install.current_removed":         "⚠️  已移除 current 链接，模块 %s 当前没有激活的版本",
+	"install.done":                    "\n✅ 已安装 %s@%s 到 %s",
+	"install.entry_error":             "包中的 %s %w",
+	"install.failed":                  "安装失败: %v",
+	"install.illegal_path":            "包中包含非法路径 %s",
+	"install.index_checksum_mismatch": "%s 的校验和与索引不匹配: 期望 %s，实际 %s",
+	"install.insecure":                "⚠️  未配置受信任的公钥，--insecure 已跳过签名检查",
+	"install.invalid_version":         "包中的版本号 %q 无效",
+	"install.module_not_found":        "模块仓库中没有模块 %s",
+	"install.module_not_installed":    "模块 %s 未安装",
+	"install.no_checksum":             "⚠️  未找到校验和，跳过校验和检查",
+	"install.no_lower_version":        "没有比当前版本 %s 更低的已安装版本",
+	"install.no_platform_version":     "模块 %s 没有适用于 %s 的版本",
+	"install.no_trusted_keys":         "未配置受信任的公钥，无法校验包的签名，请使用 --trusted 或 signing.trusted_keys 指定，或使用 --insecure 跳过签名检查",
+	"install.parse_index_failed":      "解析模块仓库索引失败: %w",
+	"install.platform_mismatch":       "模块包的平台 %s/%s 与本机 %s/%s 不一致",
+	"install.read_index_failed":       "读取模块仓库索引失败: %w",
+	"install.repo_required":           "安装 %s 需要使用 --repo 指定本地模块仓库",
+	"install.resolved":                "从模块仓库解析 %s@%s: %s",
+	"install.rollback_failed":         "回滚失败: %v",
+	"install.rolled_back":             "✅ 已将 %s 从 %s 回滚到 %s",
+	"install.switch_failed":           "切换 current 链接失败: %w",
+	"install.symlink_absolute":        "包中的符号链接 %s 指向绝对路径 %s",
+	"install.symlink_escapes":         "包中的符号链接 %s 指向包外部",
+	"install.symlink_failed":          "创建符号链接失败: %w",
+	"install.symlink_unresolved":      "包中的符号链接 %s 无法解析: %w",
+	"install.through_symlink":         "会经过符号链接 %s 写入",
+	"install.uninstall_failed":        "卸载失败: %v",
+	"install.uninstalled":             "✅ 已卸载 %s@%s",
+	"install.uninstalled_all":         "✅ 已卸载模块 %s 的所有版本",
+	"install.unsupported_type":        "包中的 %s 类型不受支持 (%c)",
+	"install.version_in_use":          "%s@%s 是当前使用的版本，请先回滚或使用 --force",
+	"install.version_not_installed":   "%s@%s 未安装",
+
+	"log.cache_key":     "缓存键 %s: %s",
+	"log.exec":          "执行: %s",
+	"log.hook_env":      "钩子环境变量: %s",
+	"log.open_failed":   "打开日志文件失败: %w",
+	"log.verbose_quiet": "-v 和 -q 不能同时使用",
+
+	"name.dotdot":          "不能包含 \"..\"",
+	"name.empty":           "%s不能为空",
+	"name.invalid":         "无效的%s %q: %s",
+	"name.invalid_chars":   "只能包含字母、数字、'.'、'_' 和 '-'，且必须以字母或数字开头",
+	"name.invalid_suggest": "无效的%s %q: %s，建议使用 %q",
+	"name.kind.executable": "可执行文件名称",
+	"name.kind.module":     "模块名称",
+	"name.kind.project":    "项目名称",
+	"name.outside_dir":     "路径 %s 不在 %s 目录内",
+	"name.reserved":        "是 Windows 的保留名称",
+	"name.too_long":        "长度不能超过 %d 个字符",
+	"name.trailing_dot":    "不能以 '.' 结尾",
+
+	"patch.apply_failed":         "应用差分包失败: %v",
+	"patch.created":              "\n✅ 已生成差分包 %s (%.2f MB，完整包 %.2f MB)",
+	"patch.diff_failed":          "生成差分包失败: %v",
+	"patch.digest_matched":       "包摘要: 与 %s@%s 一致 (%s)",
+	"patch.digest_mismatch":      "重建的包摘要 %s 与 %s@%s 的摘要 %s 不一致",
+	"patch.file_digest_mismatch": "重建的 %s 摘要不匹配",
+	"patch.header_mismatch":      "差分包中 %s 的 tar 头名称 %s 与路径不一致",
+	"patch.invalid_action":       "%s 的变更类型 %q 无效",
+	"patch.mismatch":             "只能为同一模块同一平台的包生成差分: %s %s/%s 与 %s %s/%s",
+	"patch.missing_delta":        "差分包中缺少 deltas/%s.delta",
+	"patch.missing_file":         "差分包中缺少 files/%s",
+	"patch.missing_old":          "旧包中缺少 %s",
+	"patch.no_header":            "%s 缺少 tar 头信息",
+	"patch.old_mismatch":         "旧包与差分包不匹配: 期望 %s@%s (%s)，实际摘要 %s",
+	"patch.old_mismatch_forced":  "⚠️  旧包的摘要与差分包记录不一致，继续应用",
+	"patch.parse_meta_failed":    "解析 %s 失败: %w",
+	"patch.rebuilt":              "\n✅ 已重建 %s@%s: %s",
+	"patch.summary":              "文件变更: 未变 %d，新增 %d，差分 %d，替换 %d，删除 %d",
+	"patch.unclean_path":         "差分包中的路径 %s 不规范",
+	"patch.unsupported_format":   "不支持的差分包格式: %s",
+	"patch.verified":             "已校验 %d 个文件的摘要",
+
+	"progress.cached":    "完成 %s (缓存)",
+	"progress.compiling": "编译 %s (%d/%d)",
+	"progress.done":      "完成 %s",
+	"progress.failed":    "失败: %s",
+	"progress.header":    "构建进度 %d/%d (%s)",
+	"progress.packaging": "打包中",
+	"progress.queued":    "等待中",
+
+	"publish.auth_failed":         "%w: 认证失败 (%s)",
+	"publish.content_differs":     "已发布的文件内容不同",
+	"publish.done":                "\n发布完成: 上传 %d 个文件，跳过 %d 个已发布的文件",
+	"publish.dry_run":             "将要上传以下文件 (dry-run):",
+	"publish.failed":              "发布失败: %v",
+	"publish.head_failed":         "查询 %s 失败: %s",
+	"publish.manifest_incomplete": "%s 的 manifest.json 缺少 name 或 version",
+	"publish.no_packages":         "%s 中没有可发布的模块包，请先运行 dscli build",
+	"publish.no_token":            "⚠️  环境变量 %s 未设置，上传时不会携带访问令牌",
+	"publish.no_url":              "未配置模块仓库地址，请使用 --url 或 .dscli.json 的 publish.url",
+	"publish.rejected":            "服务端拒绝上传",
+	"publish.resume":              "ℹ️  从 %d 字节处继续上传 %s",
+	"publish.retry":               "⚠️  %v，%s 后重试 (%d/%d)",
+	"publish.server_error":        "服务端返回 %s: %s",
+	"publish.skipped":             "ℹ️  已发布，跳过: %s",
+	"publish.upload_failed":       "上传 %s 失败: %w",
+	"publish.uploaded":            "✅ 已上传: %s",
+
+	"repo.duplicate":           "⚠️  %s@%s (%s) 重复: 保留 %s，忽略 %s",
+	"repo.index_failed":        "生成索引失败: %v",
+	"repo.index_written":       "✅ 已生成 %s (%d 个模块，%d 个包)",
+	"repo.invalid_package":     "⚠️  跳过无效的模块包 %s: %v",
+	"repo.manifest_incomplete": "⚠️  跳过 %s: manifest.json 缺少 name、version、os 或 arch",
+	"repo.write_failed":        "写入 %s 失败: %v",
+
+	"root.invalid_output":   "无效的输出格式 %q，可选值: text、json、ndjson",
+	"root.unknown_command":  "未知命令 \"%s\"",
+	"root.unsupported_lang": "不支持的语言 %q，可选值: zh-CN、en",
+
+	"sbom.buildinfo_failed": "读取 %s 的构建信息失败: %w",
+
+	"scaffold.exe.running":         "%s is running",
+	"scaffold.exe.starting":        "Starting %s...",
+	"scaffold.exe.todo":            "在这里实现您的 %s 逻辑",
+	"scaffold.main.cleanup":        "清理逻辑在这里",
+	"scaffold.main.config_warning": "警告: 无法读取配置文件: %v",
+	"scaffold.main.load_config":    "加载配置",
+	"scaffold.main.logic":          "在这里编写您的主要服务逻辑",
+	"scaffold.main.loop":           "主服务循环",
+	"scaffold.main.running":        "服务正在运行...",
+	"scaffold.main.setup_shutdown": "设置优雅关闭",
+	"scaffold.main.shutting_down":  "正在优雅关闭...",
+	"scaffold.main.starting":       "启动 %s v%%s (构建时间: %%s)",
+	"scaffold.main.stopped":        "服务已停止。",
+	"scaffold.main.wait_signal":    "等待关闭信号",
+
+	"semver.invalid":      "无效的语义化版本号: %q",
+	"semver.unknown_part": "未知的版本递增类型: %s",
+
+	"sign.checksum_matched":        "校验和: 匹配 %s",
+	"sign.file_not_pem":            "%s 不是 PEM 格式",
+	"sign.find_failed":             "查找待签名文件失败: %v",
+	"sign.key_exists":              "%s 已存在，使用 --force 覆盖",
+	"sign.keygen_failed":           "生成密钥对失败: %v",
+	"sign.load_key_failed":         "加载私钥 %s 失败: %w",
+	"sign.no_key":                  "未指定签名私钥，请使用 --key、signing.key 或 DSCLI_SIGNING_KEY",
+	"sign.no_trusted_keys":         "没有受信任的公钥，请使用 --trusted 或 signing.trusted_keys 指定",
+	"sign.not_ed25519_private":     "不是 ed25519 私钥",
+	"sign.not_ed25519_public":      "%s 不是 ed25519 公钥",
+	"sign.not_pem":                 "不是 PEM 格式",
+	"sign.nothing":                 "没有需要签名的文件",
+	"sign.parse_public_key_failed": "解析公钥 %s 失败: %w",
+	"sign.private_key":             "私钥: %s",
+	"sign.public_key":              "公钥: %s (指纹 %s)",
+	"sign.read_signature_failed":   "读取签名文件失败: %w",
+	"sign.read_trusted_failed":     "读取受信任公钥失败: %w",
+	"sign.sign_failed":             "签名失败: %v",
+	"sign.signature_malformed":     "签名文件 %s 格式无效",
+	"sign.signature_untrusted":     "签名无效或不是由受信任的公钥签署",
+	"sign.signature_valid":         "签名: %s 签名有效",
+	"sign.signed":                  "已签名: %s",
+	"sign.signed_by":               "签名: 由公钥 %s 签署",
+	"sign.verified":                "✅ %s 校验通过",
+	"sign.verify_failed":           "❌ 校验失败: %v",
+
+	"stages.args_invalid":      "stage %s 的args必须是字符串数组",
+	"stages.done":              "✅ 阶段 %s 完成 (%s)",
+	"stages.failed":            "阶段 %s 失败: %w",
+	"stages.failed_ignored":    "⚠️  阶段 %s 失败，已忽略: %v",
+	"stages.name_required":     "stage对象必须包含name字段",
+	"stages.not_array":         "stages必须是数组格式",
+	"stages.profile_none":      "配置档案 %s 不存在，.dscli.json 中没有定义 profiles",
+	"stages.profile_not_found": "配置档案 %s 不存在，可用的档案: %s",
+	"stages.race_needs_cgo":    "⚠️  竞态检测需要 cgo，当前 CGO_ENABLED=0，测试不使用 -race",
+	"stages.race_unsupported":  "⚠️  %s 不支持竞态检测，测试不使用 -race",
+	"stages.running":           "▶ 运行阶段 %s: %s",
+	"stages.unknown":           "未知的构建阶段 %q，可选值: generate、vet、test",
+	"stages.unsupported":       "不支持的stage格式",
+
+	"toolchain.dist_list_failed": "获取工具链支持的目标平台失败 (go tool dist list): %w",
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const maxNameLength = 64
+
+var (
+	// namePattern 项目名称和可执行文件名称的合法格式
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	// slugInvalidChars 生成建议名称时需要替换的字符
+	slugInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+	// windowsReservedNames 在 Windows 上不能用作文件名的名称
+	windowsReservedNames = map[string]bool{
+		"con": true, "prn": true, "aux": true, "nul": true,
+		"com1": true, "com2": true, "com3": true, "com4": true, "com5": true,
+		"com6": true, "com7": true, "com8": true, "com9": true,
+		"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true,
+		"lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+	}
+)
+
+// validateName 校验项目名称或可执行文件名称。
+// 名称会被用作目录名、go.mod 模块名、模板中的 Go 字符串和 manifest 路径，
+// 因此只允许字母、数字、'.'、'_' 和 '-'，必须以字母或数字开头，不能包含 ".."。
+// kind 用于错误信息，如 "项目名称"。
+func validateName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf(T("name.empty"), kind)
+	}
+
+	var reason string
+	switch {
+	case len(name) > maxNameLength:
+		reason = fmt.Sprintf(T("name.too_long"), maxNameLength)
+	case !namePattern.MatchString(name):
+		reason = T("name.invalid_chars")
+	case strings.Contains(name, ".."):
+		reason = T("name.dotdot")
+	case strings.HasSuffix(name, "."):
+		reason = T("name.trailing_dot")
+	case windowsReservedNames[strings.ToLower(strings.SplitN(name, ".", 2)[0])]:
+		reason = T("name.reserved")
+	default:
+		return nil
+	}
+
+	if slug := suggestName(name); slug != "" && slug != name {
+		return fmt.Errorf(T("name.invalid_suggest"), kind, name, reason, slug)
+	}
+	return fmt.Errorf(T("name.invalid"), kind, name, reason)
+}
+
+// suggestName 将任意字符串规范化为合法的名称，无法规范化时返回空字符串
+func suggestName(name string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = slugInvalidChars.ReplaceAllString(slug, "-")
+	for strings.Contains(slug, "..") {
+		slug = strings.ReplaceAll(slug, "..", ".")
+	}
+	slug = strings.Trim(slug, "-._")
+	if len(slug) > maxNameLength {
+		slug = strings.TrimRight(slug[:maxNameLength], "-._")
+	}
+	if slug == "" || windowsReservedNames[strings.SplitN(slug, ".", 2)[0]] {
+		return ""
+	}
+	return slug
+}
+
+// surveyNameValidator 返回用于交互式提示的名称校验函数
+func surveyNameValidator(kind string) func(interface{}) error {
+	return func(val interface{}) error {
+		name, _ := val.(string)
+		return validateName(kind, name)
+	}
+}
+
+// ensureWithinDir 确保 path 位于 baseDir 内部，防止路径穿越
+func ensureWithinDir(baseDir, path string) error {
+	absBase, err := filepath.Abs(baseDir)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return fmt.Errorf(T("name.outside_dir"), path, baseDir)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"demo", "my-module", "agent_v2", "a.b", "A1", strings.Repeat("a", maxNameLength)}
+	for _, name := range valid {
+		if err := validateName("name", name); err != nil {
+			t.Errorf("validateName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"..",
+		"../evil",
+		"a/../../b",
+		"a/b",
+		`a\b`,
+		"/etc/passwd",
+		"a..b",
+		"-flag",
+		".hidden",
+		"name.",
+		"my module",
+		"con",
+		"NUL.txt",
+		"名称",
+		strings.Repeat("a", maxNameLength+1),
+	}
+	for _, name := range invalid {
+		if err := validateName("name", name); err == nil {
+			t.Errorf("validateName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	tests := map[string]string{
+		"My Module":   "my-module",
+		"../evil":     "evil",
+		"a/../../b":   "a-.-.-b",
+		"  Agent  ":   "agent",
+		"name.":       "name",
+		"con":         "",
+		"名称":          "",
+		"foo..bar":    "foo.bar",
+		"-leading-":   "leading",
+		"Hello_World": "hello_world",
+	}
+	for input, want := range tests {
+		got := suggestName(input)
+		if got != want {
+			t.Errorf("suggestName(%q) = %q, want %q", input, got, want)
+		}
+		if got != "" {
+			if err := validateName("name", got); err != nil {
+				t.Errorf("suggestion %q for %q is not valid: %v", got, input, err)
+			}
+		}
+	}
+}
+
+func TestEnsureWithinDir(t *testing.T) {
+	base := t.TempDir()
+	inside := []string{filepath.Join(base, "a"), filepath.Join(base, "a", "b", "..", "c")}
+	for _, path := range inside {
+		if err := ensureWithinDir(base, path); err != nil {
+			t.Errorf("ensureWithinDir(%q) = %v, want nil", path, err)
+		}
+	}
+
+	outside := []string{base, filepath.Join(base, ".."), filepath.Join(base, "..", "x"), filepath.Join(base, "a", "..", "..", "x"), filepath.Join(base+"-other", "x")}
+	for _, path := range outside {
+		if err := ensureWithinDir(base, path); err == nil {
+			t.Errorf("ensureWithinDir(%q) = nil, want error", path)
+		}
+	}
+}
+
+func TestCreateRejectsTraversalName(t *testing.T) {
+	root := t.TempDir()
+	work := filepath.Join(root, "work")
+	if err := os.Mkdir(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, work)
+	resetExitCode(t)
+	defer func(interactive bool) { nonInteractive = interactive }(nonInteractive)
+	nonInteractive = true
+
+	runCreate("../evil", false)
+	if _, err := os.Stat(filepath.Join(root, "evil")); !os.IsNotExist(err) {
+		t.Fatal("create must not write outside the working directory")
+	}
+	if exitCode == 0 {
+		t.Error("create with an invalid name should exit non-zero")
+	}
+}
+
+func TestAddRejectsTraversalName(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	writeFiles(t, project, map[string]string{"manifest.json": `{"name": "demo", "version": "1.0.0"}`})
+	chdir(t, project)
+	resetExitCode(t)
+
+	addCmd.Run(addCmd, []string{"../../evil"})
+	if _, err := os.Stat(filepath.Join(root, "evil")); !os.IsNotExist(err) {
+		t.Fatal("add must not write outside the project")
+	}
+	if entries, _ := os.ReadDir(project); len(entries) != 1 {
+		t.Fatalf("add with an invalid name should not create files, got %d entries", len(entries))
+	}
+}
+
+func TestDiscoverExecutablesRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"tool/main.go": "package main\n\nfunc main() {}\n"})
+	project := filepath.Join(root, "project")
+	writeFiles(t, project, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	chdir(t, project)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	tests := []Executable{
+		{Name: "tool", Source: "../tool"},
+		{Name: "tool", Source: filepath.Join(root, "tool")},
+		{Name: "../tool", Source: "."},
+	}
+	for _, exe := range tests {
+		buildConfig = &BuildConfig{Executables: []Executable{exe}}
+		if _, err := discoverExecutables("demo"); err == nil {
+			t.Errorf("discoverExecutables should reject %+v", exe)
+		}
+	}
+
+	buildConfig = &BuildConfig{Executables: []Executable{{Name: "demo", Source: "."}}}
+	if executables, err := discoverExecutables("demo"); err != nil || len(executables) != 1 {
+		t.Errorf("discoverExecutables(.) = %v, %v", executables, err)
+	}
+}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// patchFormat 差分包格式版本
+	patchFormat = "dscli-patch/1"
+	// patchMetaFile 差分包中描述文件变更的元数据
+	patchMetaFile = "patch.json"
+	// deltaMaxRatio 差分大小超过新文件大小的该比例时直接存储完整文件
+	deltaMaxRatio = 0.9
+)
+
+// 差分包中文件的变更类型
+const (
+	patchUnchanged = "unchanged" // 与旧包相同，从旧包复制
+	patchAdded     = "added"     // 新增文件，完整存储在 files/ 下
+	patchModified  = "modified"  // 修改的文件，二进制差分存储在 deltas/ 下
+	patchReplaced  = "replaced"  // 修改的文件，差分无收益时完整存储在 files/ 下
+	patchRemoved   = "removed"   // 新包中已删除的文件
+)
+
+// PatchPackageInfo 差分包两端的包信息
+type PatchPackageInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	SHA256  string `json:"sha256"`
+	File    string `json:"file"`
+}
+
+// PatchFile 差分包中一个文件的变更
+type PatchFile struct {
+	Path   string      `json:"path"`
+	Action string      `json:"action"`
+	SHA256 string      `json:"sha256,omitempty"` // 新文件内容的摘要，用于重建后校验
+	Header *tar.Header `json:"header,omitempty"` // 新包中的 tar 头，重建时原样写入
+}
+
+// PatchMeta 差分包元数据
+type PatchMeta struct {
+	Format    string           `json:"format"`
+	CreatedAt string           `json:"created_at"`
+	From      PatchPackageInfo `json:"from"`
+	To        PatchPackageInfo `json:"to"`
+	Files     []PatchFile      `json:"files"` // 按新包中的顺序排列，删除的文件排在最后
+}
+
+var (
+	patchOutput string
+	patchForce  bool
+)
+
+// diffPackageCmd 代表 diff-package 命令
+var diffPackageCmd = &cobra.Command{
+	Use:   "diff-package <old.tar.gz> <new.tar.gz>",
+	Short: "生成两个模块版本之间的差分包",
+	Long: `比较同一模块同一平台的两个版本的包，生成只包含变更内容的差分包：
+修改的可执行文件以二进制差分存储，新增的资源完整存储，并记录删除的文件和新的 manifest。
+使用 dscli apply-patch 在旧包上重建新包。`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := createPatchPackage(args[0], args[1], patchOutput); err != nil {
+			printError(T("patch.diff_failed", err))
+			return
+		}
+	},
+}
+
+// applyPatchCmd 代表 apply-patch 命令
+var applyPatchCmd = &cobra.Command{
+	Use:   "apply-patch <old.tar.gz> <patch.tar.gz>",
+	Short: "应用差分包重建新版本的模块包",
+	Long: `在旧版本的包上应用 diff-package 生成的差分包，重建新版本的包，
+并逐个校验重建后的文件摘要以及整个包的 SHA-256，摘要不一致时失败并删除重建的包。`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := applyPatchPackage(args[0], args[1], patchOutput); err != nil {
+			printError(T("patch.apply_failed", err))
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffPackageCmd)
+	rootCmd.AddCommand(applyPatchCmd)
+	diffPackageCmd.Flags().StringVarP(&patchOutput, "out", "o", "", "差分包路径 (默认为 <name>_<os>_<arch>_<old>_to_<new>.patch.tar.gz)")
+	applyPatchCmd.Flags().StringVarP(&patchOutput, "out", "o", "", "重建的包路径 (默认与新版本包同名)")
+	applyPatchCmd.Flags().BoolVar(&patchForce, "force", false, "旧包的摘要与差分包记录不一致时仍然尝试应用")
+}
+
+func createPatchPackage(oldPath, newPath, output string) error {
+	oldInfo, err := patchPackageInfo(oldPath)
+	if err != nil {
+		return err
+	}
+	newInfo, err := patchPackageInfo(newPath)
+	if err != nil {
+		return err
+	}
+	if oldInfo.Name != newInfo.Name || oldInfo.OS != newInfo.OS || oldInfo.Arch != newInfo.Arch {
+		return fmt.Errorf(T("patch.mismatch"),
+			oldInfo.Name, oldInfo.OS, oldInfo.Arch, newInfo.Name, newInfo.OS, newInfo.Arch)
+	}
+
+	oldEntries, err := readPackageEntries(oldPath)
+	if err != nil {
+		return err
+	}
+	newEntries, err := readPackageEntries(newPath)
+	if err != nil {
+		return err
+	}
+
+	oldByPath := make(map[string]packageEntry)
+	for _, entry := range oldEntries {
+		oldByPath[path.Clean(entry.Header.Name)] = entry
+	}
+
+	meta := PatchMeta{
+		Format:    patchFormat,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		From:      *oldInfo,
+		To:        *newInfo,
+	}
+	var payload []packageEntry
+	seen := make(map[string]bool)
+	counts := make(map[string]int)
+
+	for _, entry := range newEntries {
+		name := path.Clean(entry.Header.Name)
+		seen[name] = true
+		file := PatchFile{Path: name, Header: entry.Header, SHA256: sha256Hex(entry.Data)}
+
+		old, exists := oldByPath[name]
+		switch {
+		case entry.Header.Typeflag != tar.TypeReg && entry.Header.Typeflag != tar.TypeRegA:
+			// 目录和符号链接只需要 tar 头
+			file.Action = patchAdded
+			if exists {
+				file.Action = patchUnchanged
+			}
+		case !exists:
+			file.Action = patchAdded
+			payload = append(payload, patchPayload("files/"+name, entry.Data))
+		case bytes.Equal(old.Data, entry.Data):
+			file.Action = patchUnchanged
+		default:
+			delta := computeDelta(old.Data, entry.Data)
+			if float64(len(delta)) < float64(len(entry.Data))*deltaMaxRatio {
+				file.Action = patchModified
+				payload = append(payload, patchPayload("deltas/"+name+".delta", delta))
+			} else {
+				file.Action = patchReplaced
+				payload = append(payload, patchPayload("files/"+name, entry.Data))
+			}
+		}
+		counts[file.Action]++
+		meta.Files = append(meta.Files, file)
+	}
+
+	for _, entry := range oldEntries {
+		name := path.Clean(entry.Header.Name)
+		if !seen[name] {
+			meta.Files = append(meta.Files, PatchFile{Path: name, Action: patchRemoved})
+			counts[patchRemoved]++
+		}
+	}
+
+	metaData, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	entries := append([]packageEntry{patchPayload(patchMetaFile, metaData)}, payload...)
+
+	if output == "" {
+		output = fmt.Sprintf("%s_%s_%s_%s_to_%s.patch.tar.gz",
+			newInfo.Name, newInfo.OS, newInfo.Arch, oldInfo.Version, newInfo.Version)
+	}
+	if err := writePackageEntries(output, entries); err != nil {
+		return err
+	}
+
+	logInfo(T("patch.summary",
+		counts[patchUnchanged], counts[patchAdded], counts[patchModified], counts[patchReplaced], counts[patchRemoved]))
+	patchStat, err := os.Stat(output)
+	if err != nil {
+		return err
+	}
+	newStat, err := os.Stat(newPath)
+	if err != nil {
+		return err
+	}
+	logInfo(T("patch.created", output,
+		float64(patchStat.Size())/1024/1024, float64(newStat.Size())/1024/1024))
+	return nil
+}
+
+func applyPatchPackage(oldPath, patchPath, output string) error {
+	metaData, err := readPackageFile(patchPath, patchMetaFile)
+	if err != nil {
+		return err
+	}
+	var meta PatchMeta
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return fmt.Errorf(T("patch.parse_meta_failed"), patchMetaFile, err)
+	}
+	if meta.Format != patchFormat {
+		return fmt.Errorf(T("patch.unsupported_format"), meta.Format)
+	}
+
+	oldDigest, err := fileSHA256(oldPath)
+	if err != nil {
+		return err
+	}
+	if oldDigest != meta.From.SHA256 {
+		if !patchForce {
+			return fmt.Errorf(T("patch.old_mismatch"), meta.From.Name, meta.From.Version, meta.From.SHA256, oldDigest)
+		}
+		logWarn(T("patch.old_mismatch_forced"))
+	}
+
+	oldEntries, err := readPackageEntries(oldPath)
+	if err != nil {
+		return err
+	}
+	oldByPath := make(map[string][]byte)
+	for _, entry := range oldEntries {
+		oldByPath[path.Clean(entry.Header.Name)] = entry.Data
+	}
+
+	patchEntries, err := readPackageEntries(patchPath)
+	if err != nil {
+		return err
+	}
+	payload := make(map[string][]byte)
+	for _, entry := range patchEntries {
+		payload[path.Clean(entry.Header.Name)] = entry.Data
+	}
+
+	var entries []packageEntry
+	for _, file := range meta.Files {
+		if file.Action == patchRemoved {
+			continue
+		}
+		if file.Header == nil {
+			return fmt.Errorf(T("patch.no_header"), file.Path)
+		}
+		// 重建时原样写入记录的 tar 头，头中的名称必须与校验过的路径一致，防止写入穿越路径
+		if name, err := safeArchivePath(file.Path); err != nil {
+			return err
+		} else if name != file.Path {
+			return fmt.Errorf(T("patch.unclean_path"), file.Path)
+		}
+		if name, err := safeArchivePath(file.Header.Name); err != nil {
+			return err
+		} else if name != file.Path {
+			return fmt.Errorf(T("patch.header_mismatch"), file.Path, file.Header.Name)
+		}
+
+		var data []byte
+		isRegular := file.Header.Typeflag == tar.TypeReg || file.Header.Typeflag == tar.TypeRegA
+		switch {
+		case !isRegular:
+		case file.Action == patchUnchanged:
+			old, ok := oldByPath[file.Path]
+			if !ok {
+				return fmt.Errorf(T("patch.missing_old"), file.Path)
+			}
+			data = old
+		case file.Action == patchAdded || file.Action == patchReplaced:
+			stored, ok := payload["files/"+file.Path]
+			if !ok {
+				return fmt.Errorf(T("patch.missing_file"), file.Path)
+			}
+			data = stored
+		case file.Action == patchModified:
+			delta, ok := payload["deltas/"+file.Path+".delta"]
+			if !ok {
+				return fmt.Errorf(T("patch.missing_delta"), file.Path)
+			}
+			old, ok := oldByPath[file.Path]
+			if !ok {
+				return fmt.Errorf(T("patch.missing_old"), file.Path)
+			}
+			if data, err = applyDelta(old, delta); err != nil {
+				return fmt.Errorf("%s: %w", file.Path, err)
+			}
+		default:
+			return fmt.Errorf(T("patch.invalid_action"), file.Path, file.Action)
+		}
+
+		if isRegular && sha256Hex(data) != file.SHA256 {
+			return fmt.Errorf(T("patch.file_digest_mismatch"), file.Path)
+		}
+		entries = append(entries, packageEntry{Header: file.Header, Data: data})
+	}
+
+	if output == "" {
+		output = meta.To.File
+		if output == "" {
+			output = fmt.Sprintf("%s_%s_%s.tar.gz", meta.To.Name, meta.To.OS, meta.To.Arch)
+		}
+		output = filepath.Base(output)
+	}
+	if err := writePackageEntries(output, entries); err != nil {
+		return err
+	}
+
+	newDigest, err := fileSHA256(output)
+	if err != nil {
+		return err
+	}
+	logInfo(T("patch.verified", len(entries)))
+	if newDigest != meta.To.SHA256 {
+		os.Remove(output)
+		return fmt.Errorf(T("patch.digest_mismatch"), newDigest, meta.To.Name, meta.To.Version, meta.To.SHA256)
+	}
+	logInfo(T("patch.digest_matched", meta.To.Name, meta.To.Version, newDigest))
+
+	logInfo(T("patch.rebuilt", meta.To.Name, meta.To.Version, output))
+	return nil
+}
+
+// patchPackageInfo 读取包的 manifest 和摘要
+func patchPackageInfo(packagePath string) (*PatchPackageInfo, error) {
+	manifest, err := readPackageManifest(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := fileSHA256(packagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &PatchPackageInfo{SHA256: digest, File: filepath.Base(packagePath)}
+	info.Name, _ = manifest["name"].(string)
+	info.Version, _ = manifest["version"].(string)
+	info.OS, _ = manifest["os"].(string)
+	info.Arch, _ = manifest["arch"].(string)
+	return info, nil
+}
+
+// patchPayload 生成差分包中的普通文件条目
+func patchPayload(name string, data []byte) packageEntry {
+	return packageEntry{
+		Header: &tar.Header{
+			Name:     strings.TrimPrefix(name, "/"),
+			Mode:     0644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+			ModTime:  time.Now(),
+		},
+		Data: data,
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
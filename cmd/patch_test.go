@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePatchTestPackages 在 dir 中生成同一模块两个版本的包以及它们之间的差分包
+func writePatchTestPackages(t *testing.T, dir string) (oldPath, newPath, patchPath string) {
+	t.Helper()
+	oldPath = filepath.Join(dir, "app-1.0.0.tar.gz")
+	newPath = filepath.Join(dir, "app-1.1.0.tar.gz")
+	patchPath = filepath.Join(dir, "app.patch.tar.gz")
+	app := strings.Repeat("binary content ", 200)
+	writeTestPackage(t, oldPath, []tarEntry{
+		{Name: "manifest.json", Body: testManifest(t, "app", "1.0.0")},
+		{Name: "bin/app", Body: app + "v1"},
+		{Name: "README.md", Body: "old"},
+	})
+	writeTestPackage(t, newPath, []tarEntry{
+		{Name: "manifest.json", Body: testManifest(t, "app", "1.1.0")},
+		{Name: "bin/app", Body: app + "v2"},
+		{Name: "CHANGELOG.md", Body: "new"},
+	})
+	if err := createPatchPackage(oldPath, newPath, patchPath); err != nil {
+		t.Fatal(err)
+	}
+	return oldPath, newPath, patchPath
+}
+
+// rewritePatchMeta 修改差分包中的 patch.json 后重新写入差分包
+func rewritePatchMeta(t *testing.T, patchPath string, edit func(*PatchMeta)) {
+	t.Helper()
+	entries, err := readPackageEntries(patchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, entry := range entries {
+		if entry.Header.Name != patchMetaFile {
+			continue
+		}
+		var meta PatchMeta
+		if err := json.Unmarshal(entry.Data, &meta); err != nil {
+			t.Fatal(err)
+		}
+		edit(&meta)
+		data, err := json.Marshal(meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[i] = patchPayload(patchMetaFile, data)
+	}
+	if err := writePackageEntries(patchPath, entries); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyPatchPackageRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath, patchPath := writePatchTestPackages(t, dir)
+
+	output := filepath.Join(dir, "rebuilt.tar.gz")
+	if err := applyPatchPackage(oldPath, patchPath, output); err != nil {
+		t.Fatal(err)
+	}
+	want, err := fileSHA256(newPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := fileSHA256(output); err != nil || got != want {
+		t.Errorf("rebuilt package digest = %s (%v), want %s", got, err, want)
+	}
+}
+
+func TestApplyPatchPackageRejectsHeaderNameMismatch(t *testing.T) {
+	for _, name := range []string{"../../evil", "/etc/evil", "bin/other"} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			oldPath, _, patchPath := writePatchTestPackages(t, dir)
+			rewritePatchMeta(t, patchPath, func(meta *PatchMeta) {
+				for _, file := range meta.Files {
+					if file.Path == "bin/app" {
+						file.Header.Name = name
+					}
+				}
+			})
+
+			output := filepath.Join(dir, "rebuilt.tar.gz")
+			if err := applyPatchPackage(oldPath, patchPath, output); err == nil {
+				t.Fatalf("patch with header name %q should be rejected", name)
+			}
+			if _, err := os.Stat(output); !os.IsNotExist(err) {
+				t.Errorf("rejected patch should not write %s", output)
+			}
+		})
+	}
+}
+
+func TestApplyPatchPackageDigestMismatch(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, _, patchPath := writePatchTestPackages(t, dir)
+	rewritePatchMeta(t, patchPath, func(meta *PatchMeta) {
+		meta.To.SHA256 = strings.Repeat("0", 64)
+	})
+
+	output := filepath.Join(dir, "rebuilt.tar.gz")
+	if err := applyPatchPackage(oldPath, patchPath, output); err == nil {
+		t.Fatal("digest mismatch of the rebuilt package should be an error")
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("package with mismatched digest should be removed")
+	}
+}
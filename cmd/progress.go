@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 目标的构建状态
+const (
+	targetQueued    = "queued"
+	targetCompiling = "compiling"
+	targetPackaging = "packaging"
+	targetDone      = "done"
+	targetFailed    = "failed"
+)
+
+// progressInterval 进度显示的刷新间隔
+const progressInterval = 200 * time.Millisecond
+
+// targetProgress 单个目标的构建进度
+type targetProgress struct {
+	Name       string
+	State      string
+	Executable string // 正在编译的可执行文件
+	Index      int    // 正在编译第几个可执行文件，从 1 开始
+	Total      int
+	Cached     bool
+	Size       int64
+	Err        error
+	Start      time.Time
+	End        time.Time
+}
+
+// buildProgress 交互式终端中的构建进度显示。
+// 进度块固定在输出末尾，每次刷新时清除上一次绘制的行后重新绘制；
+// 警告、错误和子进程输出打印在进度块上方，信息级别的日志由进度块代替。
+type buildProgress struct {
+	mu      sync.Mutex
+	targets []*targetProgress
+	start   time.Time
+	lines   int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// progress 当前的构建进度显示，未启用时为 nil，所有方法都可以在 nil 上调用
+var progress *buildProgress
+
+// progressEnabled 判断是否使用进度显示：标准输出必须是终端，且不是结构化输出或 -q 模式
+func progressEnabled() bool {
+	return isTerminal(os.Stdout) && !structuredOutput() && logLevel >= LogInfo
+}
+
+// startProgress 开始显示目标列表的构建进度
+func startProgress(targets []BuildTarget) *buildProgress {
+	p := &buildProgress{
+		start:   time.Now(),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	for _, target := range targets {
+		p.targets = append(p.targets, &targetProgress{Name: target.OS + "/" + target.Arch, State: targetQueued})
+	}
+
+	p.mu.Lock()
+	p.draw()
+	p.mu.Unlock()
+
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.redraw()
+				p.mu.Unlock()
+			}
+		}
+	}()
+	return p
+}
+
+// finish 停止刷新并保留最终的进度
+func (p *buildProgress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	<-p.stopped
+	p.mu.Lock()
+	p.redraw()
+	p.mu.Unlock()
+}
+
+// find 返回目标的进度，调用方需要持有锁
+func (p *buildProgress) find(target BuildTarget) *targetProgress {
+	name := target.OS + "/" + target.Arch
+	for _, t := range p.targets {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// update 修改目标的进度并立即刷新
+func (p *buildProgress) update(target BuildTarget, change func(t *targetProgress)) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t := p.find(target); t != nil {
+		if t.Start.IsZero() {
+			t.Start = time.Now()
+		}
+		change(t)
+		if t.State == targetDone || t.State == targetFailed {
+			t.End = time.Now()
+		}
+	}
+	p.redraw()
+}
+
+// compiling 记录目标开始编译第 index 个可执行文件
+func (p *buildProgress) compiling(target BuildTarget, exe string, index, total int) {
+	p.update(target, func(t *targetProgress) {
+		t.State, t.Executable, t.Index, t.Total = targetCompiling, exe, index, total
+	})
+}
+
+// packaging 记录目标开始打包
+func (p *buildProgress) packaging(target BuildTarget) {
+	p.update(target, func(t *targetProgress) { t.State = targetPackaging })
+}
+
+// done 记录目标构建完成及包的大小
+func (p *buildProgress) done(target BuildTarget, packagePath string, cached bool) {
+	var size int64
+	if info, err := os.Stat(packagePath); err == nil {
+		size = info.Size()
+	}
+	p.update(target, func(t *targetProgress) { t.State, t.Size, t.Cached = targetDone, size, cached })
+}
+
+// failed 记录目标构建失败
+func (p *buildProgress) failed(target BuildTarget, err error) {
+	p.update(target, func(t *targetProgress) { t.State, t.Err = targetFailed, err })
+}
+
+// println 在进度块上方打印一行，信息级别的日志不打印
+func (p *buildProgress) println(level LogLevel, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if level == LogInfo {
+		return
+	}
+	p.clear()
+	fmt.Println(message)
+	p.draw()
+}
+
+// write 在进度块上方输出子进程的输出
+func (p *buildProgress) write(w io.Writer, data []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clear()
+	n, err := w.Write(data)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		fmt.Fprintln(w)
+	}
+	p.draw()
+	return n, err
+}
+
+// progressWriter 将子进程的输出交给进度显示，避免破坏进度块
+type progressWriter struct {
+	p *buildProgress
+	w io.Writer
+}
+
+func (pw progressWriter) Write(data []byte) (int, error) {
+	return pw.p.write(pw.w, data)
+}
+
+// clear 清除上一次绘制的进度块，调用方需要持有锁
+func (p *buildProgress) clear() {
+	if p.lines > 0 {
+		fmt.Printf("\033[%dA\033[J", p.lines)
+		p.lines = 0
+	}
+}
+
+// redraw 重新绘制进度块，调用方需要持有锁
+func (p *buildProgress) redraw() {
+	p.clear()
+	p.draw()
+}
+
+// draw 绘制进度块，调用方需要持有锁
+func (p *buildProgress) draw() {
+	width := 0
+	finished := 0
+	for _, t := range p.targets {
+		if len(t.Name) > width {
+			width = len(t.Name)
+		}
+		if t.State == targetDone || t.State == targetFailed {
+			finished++
+		}
+	}
+
+	lines := []string{T("progress.header", finished, len(p.targets), formatElapsed(time.Since(p.start)))}
+	for _, t := range p.targets {
+		lines = append(lines, fmt.Sprintf("  %-*s  %s", width, t.Name, p.describe(t)))
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	p.lines = len(lines)
+}
+
+// describe 返回目标状态的描述
+func (p *buildProgress) describe(t *targetProgress) string {
+	elapsed := ""
+	if !t.Start.IsZero() {
+		end := t.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		elapsed = "  " + formatElapsed(end.Sub(t.Start))
+	}
+
+	var text, icon, color string
+	switch t.State {
+	case targetQueued:
+		text, icon, color = T("progress.queued"), "·", "\033[2m"
+	case targetCompiling:
+		text, icon = T("progress.compiling", t.Executable, t.Index, t.Total), "⚙"
+	case targetPackaging:
+		text, icon = T("progress.packaging"), "📦"
+	case targetDone:
+		text, icon, color = T("progress.done", formatSize(t.Size)), "✅", "\033[32m"
+		if t.Cached {
+			text = T("progress.cached", formatSize(t.Size))
+		}
+	case targetFailed:
+		text, icon, color = T("progress.failed", shortError(t.Err)), "❌", "\033[31m"
+	}
+
+	if plainOutput {
+		return text + elapsed
+	}
+	line := icon + " " + text + elapsed
+	if color != "" {
+		line = color + line + "\033[0m"
+	}
+	return line
+}
+
+// formatElapsed 格式化耗时，精确到 0.1 秒
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// shortError 返回错误的第一行，过长时截断，避免进度块折行
+func shortError(err error) string {
+	message := strings.SplitN(err.Error(), "\n", 2)[0]
+	if runes := []rune(message); len(runes) > 60 {
+		message = string(runes[:60]) + "…"
+	}
+	return message
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// PublishConfig 发布到模块仓库的配置
+type PublishConfig struct {
+	URL      string `json:"url"`       // 模块仓库的上传地址，文件上传到 <url>/<name>/<version>/<file>
+	TokenEnv string `json:"token_env"` // 保存访问令牌的环境变量名，默认为 DSCLI_PUBLISH_TOKEN
+	Retries  int    `json:"retries"`   // 上传失败时的重试次数，默认为 3
+	Timeout  int    `json:"timeout"`   // 单个请求的超时时间（秒），默认为 300
+}
+
+var (
+	publishURL     string
+	publishDryRun  bool
+	publishRetries int
+)
+
+// errPublishRejected 表示服务端拒绝上传（如已存在内容不同的文件或认证失败），不应重试
+// publishRejectedError 服务端拒绝上传，错误信息在输出时按当前语言翻译
+type publishRejectedError struct{}
+
+func (publishRejectedError) Error() string { return T("publish.rejected") }
+
+var errPublishRejected error = publishRejectedError{}
+
+// publishCmd 代表 publish 命令
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "将输出目录中的模块包上传到模块仓库",
+	Long: `将输出目录中的每个模块包及其校验和 (<file>.sha256)、签名通过 HTTP PUT 上传到模块仓库。
+文件上传到 <url>/<name>/<version>/<file>，name 和 version 取自包内的 manifest.json。
+访问令牌从环境变量读取 (默认 DSCLI_PUBLISH_TOKEN)，以 Bearer 方式发送。
+已发布且校验和一致的文件会被跳过，部分上传的文件会从中断处继续上传。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := publishPackages(); err != nil {
+			printError(T("publish.failed", err))
+			return
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+	publishCmd.Flags().StringVar(&publishURL, "url", "", "模块仓库的上传地址 (覆盖 .dscli.json 中的 publish.url)")
+	publishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "只打印将要上传的文件，不实际上传")
+	publishCmd.Flags().IntVar(&publishRetries, "retries", -1, "上传失败时的重试次数 (默认取 publish.retries 或 3)")
+}
+
+// publishUpload 一个待上传的文件及其目标地址
+type publishUpload struct {
+	Path string // 本地文件路径，Data 非空时仅用于显示
+	Data []byte // 在内存中生成的文件内容，如校验和文件
+	URL  string
+}
+
+func publishPackages() error {
+	if err := loadBuildConfig(); err != nil {
+		return err
+	}
+	cfg := buildConfig.Publish
+
+	baseURL := publishURL
+	if baseURL == "" {
+		baseURL = cfg.URL
+	}
+	if baseURL == "" {
+		return errors.New(T("publish.no_url"))
+	}
+
+	tokenEnv := cfg.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = "DSCLI_PUBLISH_TOKEN"
+	}
+	token := os.Getenv(tokenEnv)
+
+	retries := publishRetries
+	if retries < 0 {
+		retries = cfg.Retries
+		if retries <= 0 {
+			retries = 3
+		}
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 300 * time.Second
+	}
+
+	uploads, err := collectUploads(buildConfig.OutputDir, baseURL)
+	if err != nil {
+		return err
+	}
+	if len(uploads) == 0 {
+		return fmt.Errorf(T("publish.no_packages"), buildConfig.OutputDir)
+	}
+
+	if publishDryRun {
+		logInfo(T("publish.dry_run"))
+		for _, upload := range uploads {
+			logInfo(fmt.Sprintf("  %s → %s", upload.Path, upload.URL))
+		}
+		if token == "" {
+			logWarn(T("publish.no_token", tokenEnv))
+		}
+		return nil
+	}
+
+	client := &http.Client{Timeout: timeout}
+	uploaded, skipped := 0, 0
+	for _, upload := range uploads {
+		done, err := uploadWithRetry(client, upload, token, retries)
+		if err != nil {
+			return fmt.Errorf(T("publish.upload_failed"), upload.Path, err)
+		}
+		if done {
+			uploaded++
+			logInfo(T("publish.uploaded", filepath.Base(upload.Path)))
+		} else {
+			skipped++
+			logInfo(T("publish.skipped", filepath.Base(upload.Path)))
+		}
+	}
+
+	logInfo(T("publish.done", uploaded, skipped))
+	return nil
+}
+
+// collectUploads 根据包内的 manifest 确定每个文件的上传地址。
+// 每个包连同其校验和文件 <file>.sha256、签名 <file>.sig 和 SBOM <file>.cdx.json 上传到对应版本的目录。
+func collectUploads(distDir, baseURL string) ([]publishUpload, error) {
+	packages, err := filepath.Glob(filepath.Join(distDir, "*.tar.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	var uploads []publishUpload
+	for _, pkg := range packages {
+		manifest, err := readPackageManifest(pkg)
+		if err != nil {
+			return nil, err
+		}
+		name, _ := manifest["name"].(string)
+		version, _ := manifest["version"].(string)
+		if name == "" || version == "" {
+			return nil, fmt.Errorf(T("publish.manifest_incomplete"), pkg)
+		}
+
+		dirURL := strings.TrimRight(baseURL, "/") + "/" + url.PathEscape(name) + "/" + url.PathEscape(version)
+		fileURL := func(path string) string {
+			return dirURL + "/" + url.PathEscape(filepath.Base(path))
+		}
+
+		digest, err := fileSHA256(pkg)
+		if err != nil {
+			return nil, err
+		}
+		checksum := fmt.Sprintf("%s  %s\n", digest, filepath.Base(pkg))
+
+		uploads = append(uploads,
+			publishUpload{Path: pkg, URL: fileURL(pkg)},
+			publishUpload{Path: pkg + ".sha256", Data: []byte(checksum), URL: fileURL(pkg + ".sha256")})
+		for _, extra := range []string{pkg + signatureSuffix, pkg + sbomSuffix} {
+			if _, err := os.Stat(extra); err == nil {
+				uploads = append(uploads, publishUpload{Path: extra, URL: fileURL(extra)})
+			}
+		}
+	}
+	return uploads, nil
+}
+
+// uploadWithRetry 上传文件，失败时按指数退避重试。
+// 返回 false 表示服务端已存在相同内容的文件而跳过上传。
+func uploadWithRetry(client *http.Client, upload publishUpload, token string, retries int) (bool, error) {
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			delay := time.Duration(1<<uint(attempt-1)) * time.Second
+			logWarn(T("publish.retry", lastErr, delay, attempt, retries))
+			time.Sleep(delay)
+		}
+
+		uploaded, err := uploadFile(client, upload, token)
+		if err == nil {
+			return uploaded, nil
+		}
+		if errors.Is(err, errPublishRejected) {
+			return false, err
+		}
+		lastErr = err
+	}
+	return false, lastErr
+}
+
+// uploadFile 上传单个文件。先用 HEAD 查询服务端状态:
+//   - 404: 文件不存在，从头上传
+//   - 200: 文件已存在，X-Checksum-Sha256 一致时跳过，不一致时返回冲突错误；
+//     服务端没有返回校验和时无法确认内容相同，重新上传整个文件
+//   - 206: 部分上传，从 Upload-Offset 指定的位置继续上传
+func uploadFile(client *http.Client, upload publishUpload, token string) (bool, error) {
+	content, size, digest, err := openUpload(upload)
+	if err != nil {
+		return false, err
+	}
+	defer content.Close()
+
+	headReq, err := http.NewRequest(http.MethodHead, upload.URL, nil)
+	if err != nil {
+		return false, err
+	}
+	setPublishAuth(headReq, token)
+	headResp, err := client.Do(headReq)
+	if err != nil {
+		return false, err
+	}
+	headResp.Body.Close()
+
+	var offset int64
+	switch headResp.StatusCode {
+	case http.StatusNotFound:
+	case http.StatusOK:
+		remote := headResp.Header.Get("X-Checksum-Sha256")
+		if remote != "" {
+			if strings.EqualFold(remote, digest) {
+				return false, nil
+			}
+			return false, fmt.Errorf("%w: %s", errPublishRejected, T("publish.content_differs"))
+		}
+	case http.StatusPartialContent:
+		offset, err = strconv.ParseInt(headResp.Header.Get("Upload-Offset"), 10, 64)
+		if err != nil || offset < 0 || offset > size {
+			offset = 0
+		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, fmt.Errorf(T("publish.auth_failed"), errPublishRejected, headResp.Status)
+	default:
+		return false, fmt.Errorf(T("publish.head_failed"), upload.URL, headResp.Status)
+	}
+
+	if _, err := content.Seek(offset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, upload.URL, io.NopCloser(content))
+	if err != nil {
+		return false, err
+	}
+	req.ContentLength = size - offset
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Checksum-Sha256", digest)
+	if offset > 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, size-1, size))
+		logInfo(T("publish.resume", offset, filepath.Base(upload.Path)))
+	}
+	setPublishAuth(req, token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	case resp.StatusCode == http.StatusConflict:
+		return false, fmt.Errorf("%w: %s", errPublishRejected, strings.TrimSpace(string(body)))
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return false, fmt.Errorf(T("publish.auth_failed"), errPublishRejected, resp.Status)
+	default:
+		return false, fmt.Errorf(T("publish.server_error"), resp.Status, strings.TrimSpace(string(body)))
+	}
+}
+
+// uploadContent 待上传内容，支持文件和内存数据
+type uploadContent interface {
+	io.ReadSeeker
+	io.Closer
+}
+
+// nopCloseReader 为内存数据提供空的 Close 方法
+type nopCloseReader struct {
+	*bytes.Reader
+}
+
+func (nopCloseReader) Close() error { return nil }
+
+// openUpload 打开待上传的内容，返回内容、大小和 SHA-256 摘要
+func openUpload(upload publishUpload) (uploadContent, int64, string, error) {
+	if upload.Data != nil {
+		sum := sha256.Sum256(upload.Data)
+		return nopCloseReader{bytes.NewReader(upload.Data)}, int64(len(upload.Data)), hex.EncodeToString(sum[:]), nil
+	}
+
+	digest, err := fileSHA256(upload.Path)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	file, err := os.Open(upload.Path)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, "", err
+	}
+	return file, info.Size(), digest, nil
+}
+
+func setPublishAuth(req *http.Request, token string) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeModuleStore 模块仓库的 httptest 替身，支持 HEAD 查询、PUT 上传和 Content-Range 续传
+type fakeModuleStore struct {
+	mu         sync.Mutex
+	files      map[string][]byte // 已完成上传的文件
+	partial    map[string][]byte // 部分上传的文件，HEAD 返回 206
+	noChecksum bool              // 为 true 时 HEAD 不返回 X-Checksum-Sha256
+	token      string
+	puts       []string // 收到的 PUT 请求，格式为 "路径 Content-Range"
+}
+
+func newFakeModuleStore(token string) *fakeModuleStore {
+	return &fakeModuleStore{files: map[string][]byte{}, partial: map[string][]byte{}, token: token}
+}
+
+func (s *fakeModuleStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		if data, ok := s.files[r.URL.Path]; ok {
+			if !s.noChecksum {
+				sum := sha256.Sum256(data)
+				w.Header().Set("X-Checksum-Sha256", hex.EncodeToString(sum[:]))
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if data, ok := s.partial[r.URL.Path]; ok {
+			w.Header().Set("Upload-Offset", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		contentRange := r.Header.Get("Content-Range")
+		s.puts = append(s.puts, strings.TrimSpace(r.URL.Path+" "+contentRange))
+		if contentRange != "" {
+			var start, end, size int
+			if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &size); err != nil || start != len(s.partial[r.URL.Path]) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			body = append(append([]byte{}, s.partial[r.URL.Path]...), body...)
+		}
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != r.Header.Get("X-Checksum-Sha256") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(s.partial, r.URL.Path)
+		s.files[r.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// publishTestProject 创建包含一个模块包的项目目录，配置为发布到 serverURL
+func publishTestProject(t *testing.T, serverURL string) string {
+	t.Helper()
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{
+		".dscli.json": fmt.Sprintf(`{"output_dir": "dist", "publish": {"url": %q, "retries": 0, "token_env": "DSCLI_TEST_PUBLISH_TOKEN"}}`, serverURL+"/modules"),
+	})
+	writeFiles(t, dir, map[string]string{"dist/.keep": ""})
+	writeTestPackage(t, filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz"), []tarEntry{
+		{Name: "manifest.json", Body: `{"name": "demo", "version": "1.2.0", "os": "linux", "arch": "amd64"}`},
+		{Name: "bin/demo", Body: strings.Repeat("binary", 1000)},
+	})
+	t.Setenv("DSCLI_TEST_PUBLISH_TOKEN", "secret")
+	return dir
+}
+
+func TestPublishUploadAndSkip(t *testing.T) {
+	store := newFakeModuleStore("secret")
+	server := httptest.NewServer(store)
+	defer server.Close()
+	dir := publishTestProject(t, server.URL)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	if err := publishPackages(); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	for _, name := range []string{"demo_linux_amd64.tar.gz", "demo_linux_amd64.tar.gz.sha256"} {
+		if _, ok := store.files["/modules/demo/1.2.0/"+name]; !ok {
+			t.Errorf("%s was not uploaded, got %v", name, store.puts)
+		}
+	}
+	pkg, _ := os.ReadFile(filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz"))
+	if string(store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"]) != string(pkg) {
+		t.Error("uploaded package differs from the local package")
+	}
+
+	// 第二次发布时服务端已有相同内容，全部跳过
+	store.puts = nil
+	if err := publishPackages(); err != nil {
+		t.Fatalf("second publish: %v", err)
+	}
+	if len(store.puts) != 0 {
+		t.Errorf("already published files should be skipped, got uploads %v", store.puts)
+	}
+}
+
+func TestPublishRejectsDifferentContent(t *testing.T) {
+	store := newFakeModuleStore("secret")
+	server := httptest.NewServer(store)
+	defer server.Close()
+	dir := publishTestProject(t, server.URL)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	pkg, _ := os.ReadFile(filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz"))
+	other := make([]byte, len(pkg))
+	store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"] = other
+
+	if err := publishPackages(); err == nil {
+		t.Fatal("publishing over a different build of the same version should fail")
+	}
+	if string(store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"]) != string(other) {
+		t.Error("published file must not be overwritten")
+	}
+}
+
+func TestPublishWithoutRemoteChecksumReuploads(t *testing.T) {
+	store := newFakeModuleStore("secret")
+	store.noChecksum = true
+	server := httptest.NewServer(store)
+	defer server.Close()
+	dir := publishTestProject(t, server.URL)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	// 服务端已有大小相同但内容不同的文件，且不返回校验和，不能视为已发布
+	pkg, _ := os.ReadFile(filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz"))
+	store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"] = make([]byte, len(pkg))
+
+	if err := publishPackages(); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if string(store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"]) != string(pkg) {
+		t.Fatal("file with unknown remote digest should be re-uploaded")
+	}
+}
+
+func TestPublishResumesPartialUpload(t *testing.T) {
+	store := newFakeModuleStore("secret")
+	server := httptest.NewServer(store)
+	defer server.Close()
+	dir := publishTestProject(t, server.URL)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	pkg, _ := os.ReadFile(filepath.Join(dir, "dist", "demo_linux_amd64.tar.gz"))
+	half := len(pkg) / 2
+	store.partial["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"] = pkg[:half]
+
+	if err := publishPackages(); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	want := fmt.Sprintf("/modules/demo/1.2.0/demo_linux_amd64.tar.gz bytes %d-%d/%d", half, len(pkg)-1, len(pkg))
+	found := false
+	for _, put := range store.puts {
+		found = found || put == want
+	}
+	if !found {
+		t.Errorf("expected resumed upload %q, got %v", want, store.puts)
+	}
+	if string(store.files["/modules/demo/1.2.0/demo_linux_amd64.tar.gz"]) != string(pkg) {
+		t.Error("resumed package differs from the local package")
+	}
+}
+
+func TestPublishAuthFailureIsNotRetried(t *testing.T) {
+	store := newFakeModuleStore("other-token")
+	server := httptest.NewServer(store)
+	defer server.Close()
+	publishTestProject(t, server.URL)
+	defer func(config *BuildConfig, retries int) { buildConfig, publishRetries = config, retries }(buildConfig, publishRetries)
+	publishRetries = 3
+
+	if err := publishPackages(); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("publish with a wrong token should fail with 401, got %v", err)
+	}
+}
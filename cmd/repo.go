@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// repoIndexFileName 模块仓库索引文件名
+const repoIndexFileName = "index.json"
+
+// RepoIndex 模块仓库索引，按模块名称、版本和平台分组
+type RepoIndex struct {
+	GeneratedAt string                 `json:"generated_at"`
+	Modules     map[string]*RepoModule `json:"modules"`
+}
+
+// RepoModule 一个模块的所有版本
+type RepoModule struct {
+	Latest            string                             `json:"latest"`              // 最新的正式版本（没有正式版本时为最新的预发布版本）
+	LatestForPlatform map[string]string                  `json:"latest_for_platform"` // 每个 os/arch 可用的最新版本
+	Versions          map[string]map[string]RepoArtifact `json:"versions"`            // 版本 → os/arch → 包
+}
+
+// RepoArtifact 一个平台的模块包
+type RepoArtifact struct {
+	File      string `json:"file"`                 // 相对于索引文件的路径
+	SHA256    string `json:"sha256"`               // 包的 SHA-256 摘要
+	Size      int64  `json:"size"`                 // 包的大小（字节）
+	BuildDate string `json:"build_date,omitempty"` // 构建时间
+	Signature string `json:"signature,omitempty"`  // 分离签名文件的相对路径
+}
+
+var repoIndexOutput string
+
+// repoCmd 代表 repo 命令
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "管理本地模块仓库",
+	Long:  `管理由静态文件组成的本地模块仓库。`,
+}
+
+// repoIndexCmd 代表 repo index 命令
+var repoIndexCmd = &cobra.Command{
+	Use:   "index <dir>",
+	Short: "为模块包目录生成 index.json",
+	Long: `扫描目录（包括子目录）中的所有 dsserv 模块包，读取包内的 manifest.json，
+生成按模块名称、版本、操作系统和架构分组的 index.json，
+使 Agent 或任何静态 HTTP 服务器都可以解析指定平台的最新版本。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output := repoIndexOutput
+		if output == "" {
+			output = filepath.Join(args[0], repoIndexFileName)
+		}
+
+		index, err := buildRepoIndex(args[0])
+		if err != nil {
+			printError(T("repo.index_failed", err))
+			return
+		}
+
+		data, err := json.MarshalIndent(index, "", "  ")
+		if err != nil {
+			printError(T("repo.index_failed", err))
+			return
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			printError(T("repo.write_failed", output, err))
+			return
+		}
+
+		count := 0
+		for _, module := range index.Modules {
+			for _, platforms := range module.Versions {
+				count += len(platforms)
+			}
+		}
+		logInfo(T("repo.index_written", output, len(index.Modules), count))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+	repoCmd.AddCommand(repoIndexCmd)
+	repoIndexCmd.Flags().StringVarP(&repoIndexOutput, "out", "o", "", "索引文件路径 (默认为 <dir>/index.json)")
+}
+
+// buildRepoIndex 扫描目录中的模块包并生成索引
+func buildRepoIndex(dir string) (*RepoIndex, error) {
+	index := &RepoIndex{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Modules:     make(map[string]*RepoModule),
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".tar.gz") {
+			return nil
+		}
+
+		manifest, err := readPackageManifest(path)
+		if err != nil {
+			logWarn(T("repo.invalid_package", path, err))
+			return nil
+		}
+		name, _ := manifest["name"].(string)
+		version, _ := manifest["version"].(string)
+		goos, _ := manifest["os"].(string)
+		arch, _ := manifest["arch"].(string)
+		if name == "" || version == "" || goos == "" || arch == "" {
+			logWarn(T("repo.manifest_incomplete", path))
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		digest, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+
+		artifact := RepoArtifact{
+			File:   filepath.ToSlash(rel),
+			SHA256: digest,
+			Size:   info.Size(),
+		}
+		artifact.BuildDate, _ = manifest["build_date"].(string)
+		if _, err := os.Stat(path + signatureSuffix); err == nil {
+			artifact.Signature = filepath.ToSlash(rel + signatureSuffix)
+		}
+
+		module, ok := index.Modules[name]
+		if !ok {
+			module = &RepoModule{
+				LatestForPlatform: make(map[string]string),
+				Versions:          make(map[string]map[string]RepoArtifact),
+			}
+			index.Modules[name] = module
+		}
+		platforms, ok := module.Versions[version]
+		if !ok {
+			platforms = make(map[string]RepoArtifact)
+			module.Versions[version] = platforms
+		}
+
+		platform := goos + "/" + arch
+		if existing, ok := platforms[platform]; ok {
+			logWarn(T("repo.duplicate", name, version, platform, existing.File, artifact.File))
+			return nil
+		}
+		platforms[platform] = artifact
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, module := range index.Modules {
+		for version, platforms := range module.Versions {
+			if isNewerRelease(version, module.Latest) {
+				module.Latest = version
+			}
+			for platform := range platforms {
+				if isNewerRelease(version, module.LatestForPlatform[platform]) {
+					module.LatestForPlatform[platform] = version
+				}
+			}
+		}
+	}
+
+	return index, nil
+}
+
+// isNewerRelease 判断 candidate 是否应取代 current 成为最新版本。
+// 正式版本优先于预发布版本；无法解析为语义化版本时按字符串比较。
+func isNewerRelease(candidate, current string) bool {
+	if current == "" {
+		return true
+	}
+
+	cv, cErr := parseSemVer(candidate)
+	ov, oErr := parseSemVer(current)
+	if cErr != nil || oErr != nil {
+		return candidate > current
+	}
+
+	cPre, oPre := len(cv.Prerelease) > 0, len(ov.Prerelease) > 0
+	if cPre != oPre {
+		return !cPre
+	}
+	return cv.Compare(ov) > 0
+}
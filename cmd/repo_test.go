@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// repoTestManifest 生成指定平台的 manifest.json 内容
+func repoTestManifest(t *testing.T, version, goos, arch string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]string{"name": "app", "version": version, "os": goos, "arch": arch, "build_date": "2024-01-01T00:00:00Z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBuildRepoIndex(t *testing.T) {
+	dir := t.TempDir()
+	packages := map[string]string{
+		"app-1.9.0-linux-amd64.tar.gz":          repoTestManifest(t, "1.9.0", "linux", "amd64"),
+		"app-1.9.0-darwin-arm64.tar.gz":         repoTestManifest(t, "1.9.0", "darwin", "arm64"),
+		"v1.10.0/app-1.10.0-linux-amd64.tar.gz": repoTestManifest(t, "1.10.0", "linux", "amd64"),
+		"app-2.0.0-rc.1-linux-amd64.tar.gz":     repoTestManifest(t, "2.0.0-rc.1", "linux", "amd64"),
+	}
+	for name, manifest := range packages {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeTestPackage(t, path, []tarEntry{{Name: "manifest.json", Body: manifest}, {Name: "bin/app", Body: name}})
+	}
+	writeFiles(t, dir, map[string]string{
+		"v1.10.0/app-1.10.0-linux-amd64.tar.gz" + signatureSuffix: "signature",
+		"broken.tar.gz": "not a package",
+	})
+
+	index, err := buildRepoIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Modules) != 1 {
+		t.Fatalf("modules = %d, want 1", len(index.Modules))
+	}
+	module := index.Modules["app"]
+	if module == nil {
+		t.Fatal("module app missing from index")
+	}
+
+	// 1.10.0 按语义化版本新于 1.9.0，预发布版本 2.0.0-rc.1 不作为最新版本
+	if module.Latest != "1.10.0" {
+		t.Errorf("latest = %s, want 1.10.0", module.Latest)
+	}
+	wantPlatform := map[string]string{"linux/amd64": "1.10.0", "darwin/arm64": "1.9.0"}
+	for platform, want := range wantPlatform {
+		if got := module.LatestForPlatform[platform]; got != want {
+			t.Errorf("latest for %s = %s, want %s", platform, got, want)
+		}
+	}
+	if len(module.Versions) != 3 {
+		t.Errorf("versions = %d, want 3", len(module.Versions))
+	}
+
+	for name := range packages {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		var manifest map[string]string
+		if err := json.Unmarshal([]byte(packages[name]), &manifest); err != nil {
+			t.Fatal(err)
+		}
+		artifact, ok := module.Versions[manifest["version"]][manifest["os"]+"/"+manifest["arch"]]
+		if !ok {
+			t.Errorf("%s missing from index", name)
+			continue
+		}
+		digest, err := fileSHA256(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if artifact.File != name || artifact.SHA256 != digest || artifact.Size != info.Size() || artifact.BuildDate != "2024-01-01T00:00:00Z" {
+			t.Errorf("%s: artifact = %+v, want file %s, sha256 %s, size %d", name, artifact, name, digest, info.Size())
+		}
+	}
+
+	signed := module.Versions["1.10.0"]["linux/amd64"]
+	if signed.Signature != "v1.10.0/app-1.10.0-linux-amd64.tar.gz"+signatureSuffix {
+		t.Errorf("signature = %q, want the detached signature next to the package", signed.Signature)
+	}
+	if unsigned := module.Versions["1.9.0"]["linux/amd64"]; unsigned.Signature != "" {
+		t.Errorf("unsigned package has signature %q", unsigned.Signature)
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
@@ -10,51 +12,89 @@ var rootCmd = &cobra.Command{
 	Short: "dscli 是一个用于 dsserv 模块开发的脚手架工具",
 	Long: `dscli 是一个用于创建和构建 dsserv 模块的 CLI 工具。
 它提供类似 vue-cli 的项目脚手架和构建命令。`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkLangFlag(cmd); err != nil {
+			return err
+		}
+		if err := setupOutput(cmd); err != nil {
+			return err
+		}
+		return setupLogging(cmd)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		finishOutput()
+		closeLogging()
+	},
 }
 
 // Execute 将所有子命令添加到根命令并适当设置标志。
 // 这由 main.main() 调用。对于 rootCmd 只需要执行一次。
 func Execute() error {
-	return rootCmd.Execute()
+	setupLanguage(os.Args[1:])
+	if err := rootCmd.Execute(); err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// exitCode 命令执行完成后进程的退出状态码
+var exitCode int
+
+// setExitCode 记录命令失败时的退出状态码。
+// 命令的 Run 不直接调用 os.Exit，以便 PersistentPostRun 输出结构化结果并关闭日志文件，
+// 进程在 Execute 返回前以该状态码退出。
+func setExitCode(code int) {
+	exitCode = code
 }
 
 func init() {
 	// 禁用默认的 completion 命令
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	
+	// 帮助模板中的标题通过 T 翻译
+	cobra.AddTemplateFunc("T", T)
+
 	// 设置自定义帮助模板
 	rootCmd.SetHelpTemplate(`{{with (or .Long .Short)}}{{. | trimTrailingWhitespaces}}
 
 {{end}}{{if or .Runnable .HasSubCommands}}{{.UsageString}}{{end}}`)
 	
 	// 设置自定义使用模板
-	rootCmd.SetUsageTemplate(`用法:{{if .Runnable}}
+	rootCmd.SetUsageTemplate(`{{T "help.usage"}}{{if .Runnable}}
   {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
   {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
 
-别名:
+{{T "help.aliases"}}
   {{.NameAndAliases}}{{end}}{{if .HasExample}}
 
-示例:
+{{T "help.examples"}}
 {{.Example}}{{end}}{{if .HasAvailableSubCommands}}
 
-可用命令:{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+{{T "help.commands"}}{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
   {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
 
-选项:
+{{T "help.flags"}}
 {{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
 
-全局选项:
+{{T "help.global_flags"}}
 {{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
 
-其他帮助主题:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+{{T "help.topics"}}{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
   {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
 
-使用 "{{.CommandPath}} [command] --help" 获取命令的更多信息。{{end}}
+{{T "help.more" .CommandPath}}{{end}}
 `)
 	
 	// 自定义帮助标志的描述
 	rootCmd.PersistentFlags().BoolP("help", "h", false, "显示 dscli 的帮助信息")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "输出格式: text、json 或 ndjson (build、create、add、audit、compare 输出结构化事件)")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "界面语言: zh-CN 或 en (默认取 DSCLI_LANG 或 LANG)")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "输出详细信息，-v 显示执行的命令，-vv 显示缓存键和钩子环境变量")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "只输出警告和错误")
+	rootCmd.PersistentFlags().StringVar(&logFilePath, "log-file", "", "将完整的输出记录写入指定文件，不受 -q 和 -v 影响")
 	
 	// 添加自定义的帮助命令
 	helpCmd := &cobra.Command{
@@ -67,7 +107,7 @@ func init() {
 			} else {
 				subCmd, _, err := rootCmd.Find(args)
 				if err != nil {
-					cmd.Printf("未知命令 \"%s\"\n", args[0])
+					cmd.Println(T("root.unknown_command", args[0]))
 					return
 				}
 				subCmd.Help()
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// sbomFileName 模块包内 SBOM 文件的路径
+	sbomFileName = "sbom.cdx.json"
+	// sbomSuffix 输出目录中 SBOM 文件的后缀，与包同名
+	sbomSuffix = ".cdx.json"
+)
+
+// CycloneDXBOM CycloneDX 1.5 JSON 格式的软件物料清单
+type CycloneDXBOM struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDXMetadata     `json:"metadata"`
+	Components   []CycloneDXComponent  `json:"components"`
+	Dependencies []CycloneDXDependency `json:"dependencies"`
+}
+
+// CycloneDXMetadata SBOM 元数据，component 描述模块本身
+type CycloneDXMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []CycloneDXComponent `json:"components"`
+	} `json:"tools"`
+	Component CycloneDXComponent `json:"component"`
+}
+
+// CycloneDXComponent SBOM 中的组件
+type CycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Hashes     []CycloneDXHash     `json:"hashes,omitempty"`
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+}
+
+// CycloneDXHash 组件摘要
+type CycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// CycloneDXProperty 组件的附加属性
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDXDependency 组件之间的依赖关系
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// sbomEnabled 判断构建时是否生成 SBOM，默认启用
+func sbomEnabled() bool {
+	return buildConfig == nil || buildConfig.SBOM == nil || *buildConfig.SBOM
+}
+
+// generateSBOM 读取每个可执行文件的 Go 构建信息，生成 CycloneDX SBOM。
+// 模块名称和版本取自 manifest。
+func generateSBOM(manifest map[string]interface{}, binaryPaths []string) ([]byte, error) {
+	name, _ := manifest["name"].(string)
+	version, _ := manifest["version"].(string)
+	goos, _ := manifest["os"].(string)
+	arch, _ := manifest["arch"].(string)
+
+	moduleRef := fmt.Sprintf("pkg:generic/%s@%s", name, version)
+	bom := CycloneDXBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Components:   []CycloneDXComponent{},
+		Dependencies: []CycloneDXDependency{},
+	}
+	bom.Metadata.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	bom.Metadata.Tools.Components = []CycloneDXComponent{{Type: "application", Name: "dscli", Version: Version}}
+	bom.Metadata.Component = CycloneDXComponent{
+		Type:    "application",
+		BOMRef:  moduleRef,
+		Name:    name,
+		Version: version,
+		PURL:    moduleRef,
+		Properties: []CycloneDXProperty{
+			{Name: "dscli:os", Value: goos},
+			{Name: "dscli:arch", Value: arch},
+		},
+	}
+
+	libraries := make(map[string]CycloneDXComponent)
+	var executableRefs []string
+
+	for _, binaryPath := range binaryPaths {
+		data, err := os.ReadFile(binaryPath)
+		if err != nil {
+			return nil, err
+		}
+		info, err := readBuildInfo(data)
+		if err != nil {
+			return nil, fmt.Errorf(T("sbom.buildinfo_failed"), binaryPath, err)
+		}
+
+		exeName := filepath.Base(binaryPath)
+		exeRef := fmt.Sprintf("%s#bin/%s", moduleRef, exeName)
+		executableRefs = append(executableRefs, exeRef)
+
+		exe := CycloneDXComponent{
+			Type:    "application",
+			BOMRef:  exeRef,
+			Name:    "bin/" + exeName,
+			Version: version,
+			Hashes:  []CycloneDXHash{{Alg: "SHA-256", Content: sha256Hex(data)}},
+			Properties: []CycloneDXProperty{
+				{Name: "dscli:go_version", Value: info.GoVersion},
+				{Name: "dscli:main_module", Value: info.Main.Path},
+				{Name: "dscli:package", Value: info.Path},
+			},
+		}
+		bom.Components = append(bom.Components, exe)
+
+		// Go 标准库也作为依赖记录，便于按 Go 版本审计漏洞
+		stdlib := goModuleComponent("stdlib", info.GoVersion)
+		libraries[stdlib.BOMRef] = stdlib
+		dependsOn := []string{stdlib.BOMRef}
+
+		for _, dep := range info.Deps {
+			lib := goModuleComponent(dep.Path, dep.Version)
+			if dep.Replace != nil {
+				lib = goModuleComponent(dep.Replace.Path, dep.Replace.Version)
+				lib.Properties = append(lib.Properties, CycloneDXProperty{Name: "dscli:replaces", Value: dep.Path + "@" + dep.Version})
+			}
+			libraries[lib.BOMRef] = lib
+			dependsOn = append(dependsOn, lib.BOMRef)
+		}
+		sort.Strings(dependsOn)
+		bom.Dependencies = append(bom.Dependencies, CycloneDXDependency{Ref: exeRef, DependsOn: dependsOn})
+	}
+
+	refs := make([]string, 0, len(libraries))
+	for ref := range libraries {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		bom.Components = append(bom.Components, libraries[ref])
+	}
+	bom.Dependencies = append([]CycloneDXDependency{{Ref: moduleRef, DependsOn: executableRefs}}, bom.Dependencies...)
+
+	return json.MarshalIndent(bom, "", "  ")
+}
+
+// goModuleComponent 生成 Go 模块组件，bom-ref 使用 purl
+func goModuleComponent(path, version string) CycloneDXComponent {
+	purl := "pkg:golang/" + path
+	if version != "" {
+		purl += "@" + version
+	}
+	return CycloneDXComponent{
+		Type:    "library",
+		BOMRef:  purl,
+		Name:    path,
+		Version: version,
+		PURL:    purl,
+	}
+}
+
+// newUUID 生成随机的 UUID v4
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "00000000-0000-4000-8000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverPattern 语义化版本 2.0.0 的格式，允许可选的 "v" 前缀
+var semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[A-Za-z-][0-9A-Za-z-]*)(?:\.(?:0|[1-9]\d*|\d*[A-Za-z-][0-9A-Za-z-]*))*))?` +
+	`(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// SemVer 语义化版本号
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease []string
+	Build      string
+}
+
+// parseSemVer 解析语义化版本号，如 "1.2.3"、"v1.2.3-rc.1+build.5"
+func parseSemVer(s string) (*SemVer, error) {
+	m := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, fmt.Errorf(T("semver.invalid"), s)
+	}
+
+	v := &SemVer{Build: m[5]}
+	var err error
+	if v.Major, err = strconv.Atoi(m[1]); err != nil {
+		return nil, fmt.Errorf(T("semver.invalid"), s)
+	}
+	if v.Minor, err = strconv.Atoi(m[2]); err != nil {
+		return nil, fmt.Errorf(T("semver.invalid"), s)
+	}
+	if v.Patch, err = strconv.Atoi(m[3]); err != nil {
+		return nil, fmt.Errorf(T("semver.invalid"), s)
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+	}
+	return v, nil
+}
+
+// String 返回不带 "v" 前缀的版本字符串
+func (v *SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare 按语义化版本规则比较优先级，忽略构建元数据。
+// v < other 返回 -1，相等返回 0，v > other 返回 1。
+func (v *SemVer) Compare(other *SemVer) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	// 没有预发布标识的版本优先级更高
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := comparePrereleaseIdent(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(v.Prerelease), len(other.Prerelease))
+}
+
+// Bump 按照 major、minor、patch 或 prerelease 递增版本号。
+// 当前为预发布版本时，递增到对应的正式版本（如 2.0.0-rc.1 的 major 为 2.0.0）。
+// preid 为 prerelease 递增时使用的标识，如 "rc"。
+func (v *SemVer) Bump(part, preid string) (*SemVer, error) {
+	next := &SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	isPre := len(v.Prerelease) > 0
+
+	switch part {
+	case "major":
+		if !isPre || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+			next.Minor, next.Patch = 0, 0
+		}
+	case "minor":
+		if !isPre || v.Patch != 0 {
+			next.Minor++
+			next.Patch = 0
+		}
+	case "patch":
+		if !isPre {
+			next.Patch++
+		}
+	case "prerelease":
+		if !isPre {
+			next.Patch++
+			next.Prerelease = prereleaseStart(preid)
+			break
+		}
+		next.Prerelease = append([]string{}, v.Prerelease...)
+		if preid != "" && next.Prerelease[0] != preid {
+			next.Prerelease = prereleaseStart(preid)
+			break
+		}
+		last := len(next.Prerelease) - 1
+		if n, err := strconv.Atoi(next.Prerelease[last]); err == nil {
+			next.Prerelease[last] = strconv.Itoa(n + 1)
+		} else {
+			next.Prerelease = append(next.Prerelease, "0")
+		}
+	default:
+		return nil, fmt.Errorf(T("semver.unknown_part"), part)
+	}
+
+	return next, nil
+}
+
+func prereleaseStart(preid string) []string {
+	if preid == "" {
+		return []string{"0"}
+	}
+	return []string{preid, "0"}
+}
+
+func comparePrereleaseIdent(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInt(an, bn)
+	case aErr == nil:
+		return -1 // 数字标识的优先级低于字母标识
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
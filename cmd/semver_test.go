@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *SemVer // nil 表示应解析失败
+	}{
+		{"1.2.3", &SemVer{Major: 1, Minor: 2, Patch: 3}},
+		{"v0.0.0", &SemVer{}},
+		{" 1.2.3\n", &SemVer{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2.3-rc.1", &SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}}},
+		{"1.2.3-0.3.7", &SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"0", "3", "7"}}},
+		{"1.2.3-x-y.01a", &SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"x-y", "01a"}}},
+		{"1.2.3+build.5", &SemVer{Major: 1, Minor: 2, Patch: 3, Build: "build.5"}},
+		{"v1.2.3-beta+exp.sha.5114f85", &SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"beta"}, Build: "exp.sha.5114f85"}},
+		{"1.2", nil},
+		{"1.2.3.4", nil},
+		{"01.2.3", nil},
+		{"1.2.3-01", nil},
+		{"1.2.3-", nil},
+		{"1.2.3+", nil},
+		{"1.2.3-rc..1", nil},
+		{"V1.2.3", nil},
+	}
+	for _, tt := range tests {
+		got, err := parseSemVer(tt.input)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("parseSemVer(%q) = %v, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSemVer(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSemVer(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSemVerString(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3-rc.1", "1.2.3+build.5", "1.2.3-beta.2+exp"} {
+		v, err := parseSemVer("v" + s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	// 按语义化版本规范 11 节的示例从低到高排列
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := parseSemVer(ordered[i])
+			b, _ := parseSemVer(ordered[j])
+			if got, want := a.Compare(b), compareInt(i, j); got != want {
+				t.Errorf("%s.Compare(%s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	// 构建元数据不影响优先级
+	a, _ := parseSemVer("1.0.0+build.1")
+	b, _ := parseSemVer("1.0.0+build.2")
+	if c := a.Compare(b); c != 0 {
+		t.Errorf("1.0.0+build.1 vs 1.0.0+build.2 = %d, want 0", c)
+	}
+}
+
+func TestSemVerBump(t *testing.T) {
+	tests := []struct {
+		version, part, preid, want string
+	}{
+		{"1.2.3", "major", "", "2.0.0"},
+		{"1.2.3", "minor", "", "1.3.0"},
+		{"1.2.3", "patch", "", "1.2.4"},
+		{"1.2.3+build.5", "patch", "", "1.2.4"},
+		{"1.2.3", "prerelease", "rc", "1.2.4-rc.0"},
+		{"1.2.3", "prerelease", "", "1.2.4-0"},
+
+		// 预发布版本递增到对应的正式版本
+		{"2.0.0-rc.1", "major", "", "2.0.0"},
+		{"2.1.0-rc.1", "major", "", "3.0.0"},
+		{"1.3.0-rc.1", "minor", "", "1.3.0"},
+		{"1.3.1-rc.1", "minor", "", "1.4.0"},
+		{"1.2.4-rc.1", "patch", "", "1.2.4"},
+
+		// 预发布序号递增
+		{"1.2.4-rc.1", "prerelease", "", "1.2.4-rc.2"},
+		{"1.2.4-rc.1", "prerelease", "rc", "1.2.4-rc.2"},
+		{"1.2.4-rc.1", "prerelease", "beta", "1.2.4-beta.0"},
+		{"1.2.4-rc", "prerelease", "", "1.2.4-rc.0"},
+		{"1.2.4-0", "prerelease", "", "1.2.4-1"},
+	}
+	for _, tt := range tests {
+		v, err := parseSemVer(tt.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := v.Bump(tt.part, tt.preid)
+		if err != nil {
+			t.Errorf("%s bump %s: %v", tt.version, tt.part, err)
+			continue
+		}
+		if got := next.String(); got != tt.want {
+			t.Errorf("%s bump %s (preid %q) = %s, want %s", tt.version, tt.part, tt.preid, got, tt.want)
+		}
+	}
+
+	v, _ := parseSemVer("1.0.0")
+	if _, err := v.Bump("build", ""); err == nil {
+		t.Error("unknown bump part should be an error")
+	}
+}
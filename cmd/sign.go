@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// signatureSuffix 分离签名文件的后缀
+const signatureSuffix = ".sig"
+
+// SigningConfig 包签名配置
+type SigningConfig struct {
+	Key         string   `json:"key"`          // 签名私钥文件路径
+	TrustedKeys []string `json:"trusted_keys"` // 受信任的公钥文件或目录
+}
+
+var (
+	signKeyFile     string
+	keygenOutput    string
+	keygenForce     bool
+	verifyTrustKeys []string
+)
+
+// keygenCmd 代表 keygen 命令
+var keygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "生成用于签名模块包的 ed25519 密钥对",
+	Long: `生成用于签名模块包的 ed25519 密钥对。
+私钥保存为 <name>.key (PKCS#8 PEM，权限 0600)，公钥保存为 <name>.pub (PKIX PEM)。
+请妥善保管私钥，并将公钥分发给需要校验模块包的 Agent。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateKeyPair(keygenOutput); err != nil {
+			printError(T("sign.keygen_failed", err))
+			return
+		}
+	},
+}
+
+// signCmd 代表 sign 命令
+var signCmd = &cobra.Command{
+	Use:   "sign [file...]",
+	Short: "为模块包生成 ed25519 分离签名",
+	Long: `使用本地私钥为模块包和校验和文件生成分离签名 (<file>.sig)。
+未指定文件时，签名输出目录中的所有 .tar.gz 包和 checksums.txt。
+私钥依次从 --key、.dscli.json 的 signing.key 和 DSCLI_SIGNING_KEY 环境变量获取。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+
+		files := args
+		if len(files) == 0 {
+			var err error
+			if files, err = signableFiles(buildConfig.OutputDir); err != nil {
+				printError(T("sign.find_failed", err))
+				return
+			}
+		}
+		if len(files) == 0 {
+			logInfo(T("sign.nothing"))
+			return
+		}
+
+		if err := signFiles(files); err != nil {
+			printError(T("sign.sign_failed", err))
+			return
+		}
+	},
+}
+
+// verifyCmd 代表 verify 命令
+var verifyCmd = &cobra.Command{
+	Use:   "verify <package>",
+	Short: "校验模块包的签名和校验和",
+	Long: `使用受信任的公钥列表校验模块包的分离签名 (<package>.sig)，
+如果包所在目录存在 checksums.txt，同时校验包的 SHA-256 校验和。
+受信任的公钥来自 --trusted 和 .dscli.json 的 signing.trusted_keys，可以是公钥文件或目录。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadBuildConfig(); err != nil {
+			printError(T("common.load_config_failed", err))
+			return
+		}
+
+		if err := verifyPackage(args[0], trustedKeySources()); err != nil {
+			printError(T("sign.verify_failed", err))
+			return
+		}
+		logInfo(T("sign.verified", args[0]))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keygenCmd)
+	rootCmd.AddCommand(signCmd)
+	rootCmd.AddCommand(verifyCmd)
+
+	keygenCmd.Flags().StringVarP(&keygenOutput, "out", "o", "dscli", "密钥文件名前缀，生成 <name>.key 和 <name>.pub")
+	keygenCmd.Flags().BoolVar(&keygenForce, "force", false, "覆盖已存在的密钥文件")
+	signCmd.Flags().StringVarP(&signKeyFile, "key", "k", "", "签名私钥文件路径")
+	verifyCmd.Flags().StringSliceVar(&verifyTrustKeys, "trusted", nil, "受信任的公钥文件或目录 (可重复指定)")
+}
+
+func generateKeyPair(name string) error {
+	keyPath := name + ".key"
+	pubPath := name + ".pub"
+	if !keygenForce {
+		for _, path := range []string{keyPath, pubPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf(T("sign.key_exists"), path)
+			}
+		}
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	if err := os.WriteFile(keyPath, privPEM, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(pubPath, pubPEM, 0644); err != nil {
+		return err
+	}
+
+	logInfo(T("sign.private_key", keyPath))
+	logInfo(T("sign.public_key", pubPath, keyFingerprint(pub)))
+	return nil
+}
+
+// signingKeyPath 按 --key、配置文件、环境变量的顺序确定私钥路径
+func signingKeyPath() string {
+	if signKeyFile != "" {
+		return signKeyFile
+	}
+	if buildConfig != nil && buildConfig.Signing.Key != "" {
+		return buildConfig.Signing.Key
+	}
+	return os.Getenv("DSCLI_SIGNING_KEY")
+}
+
+// signableFiles 返回输出目录中需要签名的包和校验和文件
+func signableFiles(distDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(distDir, "*.tar.gz"))
+	if err != nil {
+		return nil, err
+	}
+	checksumPath := filepath.Join(distDir, checksumFileName)
+	if _, err := os.Stat(checksumPath); err == nil {
+		files = append(files, checksumPath)
+	}
+	return files, nil
+}
+
+// signFiles 为每个文件生成 <file>.sig，签名内容为 base64 编码的 ed25519 签名
+func signFiles(files []string) error {
+	keyPath := signingKeyPath()
+	if keyPath == "" {
+		return errors.New(T("sign.no_key"))
+	}
+	priv, err := loadPrivateKey(keyPath)
+	if err != nil {
+		return fmt.Errorf(T("sign.load_key_failed"), keyPath, err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		sig := ed25519.Sign(priv, data)
+		sigPath := file + signatureSuffix
+		if err := os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0644); err != nil {
+			return err
+		}
+		logInfo(T("sign.signed", sigPath))
+	}
+	return nil
+}
+
+// trustedKeySources 合并命令行和配置文件中的受信任公钥来源
+func trustedKeySources() []string {
+	sources := append([]string{}, verifyTrustKeys...)
+	if buildConfig != nil {
+		sources = append(sources, buildConfig.Signing.TrustedKeys...)
+	}
+	return sources
+}
+
+// verifyPackage 校验包的签名，并在存在 checksums.txt 时校验校验和
+func verifyPackage(packagePath string, trustedSources []string) error {
+	if found, err := verifyChecksum(packagePath); err != nil {
+		return err
+	} else if found {
+		logInfo(T("sign.checksum_matched", checksumFileName))
+	}
+
+	keys, err := loadTrustedKeys(trustedSources)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New(T("sign.no_trusted_keys"))
+	}
+
+	fingerprint, err := verifySignature(packagePath, keys)
+	if err != nil {
+		return err
+	}
+	logInfo(T("sign.signed_by", fingerprint))
+
+	// 校验和文件有签名时一并校验，防止校验和被篡改
+	checksumPath := filepath.Join(filepath.Dir(packagePath), checksumFileName)
+	if _, err := os.Stat(checksumPath + signatureSuffix); err == nil {
+		if _, err := verifySignature(checksumPath, keys); err != nil {
+			return fmt.Errorf("%s: %w", checksumFileName, err)
+		}
+		logInfo(T("sign.signature_valid", checksumFileName))
+	}
+	return nil
+}
+
+// verifySignature 使用受信任公钥校验文件的分离签名，返回匹配公钥的指纹
+func verifySignature(path string, keys []ed25519.PublicKey) (string, error) {
+	sigData, err := os.ReadFile(path + signatureSuffix)
+	if err != nil {
+		return "", fmt.Errorf(T("sign.read_signature_failed"), err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigData)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return "", fmt.Errorf(T("sign.signature_malformed"), path+signatureSuffix)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, data, sig) {
+			return keyFingerprint(key), nil
+		}
+	}
+	return "", errors.New(T("sign.signature_untrusted"))
+}
+
+func loadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(T("sign.not_pem"))
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New(T("sign.not_ed25519_private"))
+	}
+	return priv, nil
+}
+
+func loadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf(T("sign.file_not_pem"), path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(T("sign.parse_public_key_failed"), path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf(T("sign.not_ed25519_public"), path)
+	}
+	return pub, nil
+}
+
+// loadTrustedKeys 加载公钥文件，目录中的所有 .pub 文件都会被加载
+func loadTrustedKeys(sources []string) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for _, source := range sources {
+		info, err := os.Stat(source)
+		if err != nil {
+			return nil, fmt.Errorf(T("sign.read_trusted_failed"), err)
+		}
+
+		paths := []string{source}
+		if info.IsDir() {
+			if paths, err = filepath.Glob(filepath.Join(source, "*.pub")); err != nil {
+				return nil, err
+			}
+		}
+		for _, path := range paths {
+			key, err := loadPublicKey(path)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// keyFingerprint 返回公钥 SHA-256 摘要的前 16 位十六进制字符
+func keyFingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyAfterUnsignedRebuild(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	defer func(key string, force bool) { signKeyFile, keygenForce = key, force }(signKeyFile, keygenForce)
+
+	if err := generateKeyPair("test"); err != nil {
+		t.Fatal(err)
+	}
+	signKeyFile = "test.key"
+
+	packagePath := filepath.Join("dist", "demo_linux_amd64.tar.gz")
+	writeFiles(t, dir, map[string]string{"dist/demo_linux_amd64.tar.gz": "v1"})
+
+	// build --sign：生成校验和后签名包和校验和文件
+	if _, err := writeChecksums("dist"); err != nil {
+		t.Fatal(err)
+	}
+	files, err := signableFiles("dist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyPackage(packagePath, []string{"test.pub"}); err != nil {
+		t.Fatalf("signed build should verify: %v", err)
+	}
+
+	// 不带 --sign 重新构建：包被替换，校验和重新生成，旧的校验和签名必须删除
+	writeFiles(t, dir, map[string]string{"dist/demo_linux_amd64.tar.gz": "v2"})
+	checksumPath, err := writeChecksums("dist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(checksumPath + signatureSuffix); !os.IsNotExist(err) {
+		t.Fatalf("stale %s%s should be removed, stat error: %v", checksumFileName, signatureSuffix, err)
+	}
+
+	// 之后只签名包本身，校验不应因校验和文件的旧签名而失败
+	if err := signFiles([]string{packagePath}); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyPackage(packagePath, []string{"test.pub"}); err != nil {
+		t.Fatalf("package should verify after unsigned checksum rebuild: %v", err)
+	}
+}
+
+func TestVerifyCommandSetsExitCode(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	resetExitCode(t)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	writeFiles(t, dir, map[string]string{"demo.tar.gz": "data"})
+	verifyCmd.Run(verifyCmd, []string{"demo.tar.gz"})
+	if exitCode == 0 {
+		t.Fatal("verify should exit non-zero when the package is not signed")
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProfileConfig 构建配置档案，通过 dscli build --profile <name> 选择
+type ProfileConfig struct {
+	Stages interface{} `json:"stages"` // 覆盖顶层的 stages，空数组表示不运行任何阶段
+}
+
+// Stage 一个构建前阶段
+type Stage struct {
+	Name         string   // generate、vet 或 test
+	Args         []string // 附加的命令行参数
+	Race         bool     // test 阶段是否启用竞态检测
+	AllowFailure bool     // 为 true 时阶段失败不会中止构建
+}
+
+// StageResult 构建前阶段的执行结果，显示在构建摘要中
+type StageResult struct {
+	Name     string
+	Command  string
+	Duration time.Duration
+	Err      error
+}
+
+// raceSupported 支持 -race 的平台
+var raceSupported = map[string]bool{
+	"linux/amd64":   true,
+	"linux/arm64":   true,
+	"linux/ppc64le": true,
+	"linux/s390x":   true,
+	"linux/loong64": true,
+	"darwin/amd64":  true,
+	"darwin/arm64":  true,
+	"freebsd/amd64": true,
+	"netbsd/amd64":  true,
+	"windows/amd64": true,
+}
+
+var buildProfile string
+
+// selectedStages 返回当前配置档案的构建前阶段，未指定档案时使用顶层的 stages
+func selectedStages() ([]Stage, error) {
+	value := buildConfig.Stages
+	if buildProfile != "" {
+		profile, ok := buildConfig.Profiles[buildProfile]
+		if !ok {
+			var names []string
+			for name := range buildConfig.Profiles {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if len(names) == 0 {
+				return nil, fmt.Errorf(T("stages.profile_none"), buildProfile)
+			}
+			return nil, fmt.Errorf(T("stages.profile_not_found"), buildProfile, strings.Join(names, ", "))
+		}
+		if profile.Stages != nil {
+			value = profile.Stages
+		}
+	}
+	return parseStages(value)
+}
+
+// parseStages 解析阶段配置，支持字符串数组和对象数组两种格式
+func parseStages(value interface{}) ([]Stage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New(T("stages.not_array"))
+	}
+
+	var stages []Stage
+	for _, item := range items {
+		var stage Stage
+		if name, ok := item.(string); ok {
+			// 字符串格式：使用默认参数，测试默认启用竞态检测
+			stage = Stage{Name: name, Race: true}
+		} else if stageMap, ok := item.(map[string]interface{}); ok {
+			// 对象格式：解析name、args、race和allow_failure字段
+			name, ok := stageMap["name"].(string)
+			if !ok {
+				return nil, errors.New(T("stages.name_required"))
+			}
+			stage = Stage{Name: name, Race: true}
+			if args, ok := stageMap["args"].([]interface{}); ok {
+				for _, arg := range args {
+					argStr, ok := arg.(string)
+					if !ok {
+						return nil, fmt.Errorf(T("stages.args_invalid"), name)
+					}
+					stage.Args = append(stage.Args, argStr)
+				}
+			}
+			if race, ok := stageMap["race"].(bool); ok {
+				stage.Race = race
+			}
+			if allowFailure, ok := stageMap["allow_failure"].(bool); ok {
+				stage.AllowFailure = allowFailure
+			}
+		} else {
+			return nil, errors.New(T("stages.unsupported"))
+		}
+
+		switch stage.Name {
+		case "generate", "vet", "test":
+		default:
+			return nil, fmt.Errorf(T("stages.unknown"), stage.Name)
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// runStages 依次运行构建前阶段，未允许失败的阶段出错时停止并返回错误
+func runStages(stages []Stage) ([]StageResult, error) {
+	var results []StageResult
+	for _, stage := range stages {
+		args := stageCommand(stage)
+		result := StageResult{Name: stage.Name, Command: "go " + strings.Join(args, " ")}
+		logInfo(T("stages.running", stage.Name, result.Command))
+		start := time.Now()
+		cmd := exec.Command("go", args...)
+		cmd.Stdout, cmd.Stderr = commandOutput()
+		result.Err = cmd.Run()
+		result.Duration = time.Since(start)
+		results = append(results, result)
+
+		event := Event{Type: EventStageFinished, Stage: stage.Name, Message: result.Command, DurationMS: result.Duration.Milliseconds(), Success: boolPtr(result.Err == nil)}
+		if result.Err != nil {
+			event.Error = result.Err.Error()
+		}
+		emitEvent(event)
+
+		if result.Err != nil {
+			if stage.AllowFailure {
+				logWarn(T("stages.failed_ignored", stage.Name, result.Err))
+				continue
+			}
+			return results, fmt.Errorf(T("stages.failed"), stage.Name, result.Err)
+		}
+		logInfo(T("stages.done", stage.Name, result.Duration.Round(time.Millisecond)))
+	}
+	return results, nil
+}
+
+// stageCommand 返回阶段对应的 go 命令参数。
+// 测试始终在当前主机平台运行，只有主机支持且启用了 cgo 时才添加 -race。
+func stageCommand(stage Stage) []string {
+	args := []string{stage.Name}
+	if stage.Name == "test" {
+		if stage.Race {
+			host := runtime.GOOS + "/" + runtime.GOARCH
+			if !raceSupported[host] {
+				logWarn(T("stages.race_unsupported", host))
+			} else if !cgoEnabled() {
+				logWarn(T("stages.race_needs_cgo"))
+			} else {
+				args = append(args, "-race")
+			}
+		}
+	}
+	return append(append(args, stage.Args...), "./...")
+}
+
+// cgoEnabled 判断主机上的 go 命令是否启用了 cgo
+func cgoEnabled() bool {
+	output, err := exec.Command("go", "env", "CGO_ENABLED").Output()
+	return err == nil && strings.TrimSpace(string(output)) == "1"
+}
+
+// printStageResults 在构建摘要中输出每个阶段的结果
+func printStageResults(results []StageResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			logError(fmt.Sprintf("  ❌ %s: %s (%s) %v", result.Name, result.Command, result.Duration.Round(time.Millisecond), result.Err))
+		} else {
+			logInfo(fmt.Sprintf("  ✅ %s: %s (%s)", result.Name, result.Command, result.Duration.Round(time.Millisecond)))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+)
+
+// stageTestProject 返回一个 go vet 会报错的最小模块项目
+func stageTestProject(t *testing.T, config string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":        "module demo\n\ngo 1.21\n",
+		"manifest.json": `{"name": "demo", "version": "1.0.0"}`,
+		".dscli.json":   config,
+		"main.go":       "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Printf(\"%d\\n\", \"x\") }\n",
+	})
+	return dir
+}
+
+func TestRunStagesStopsOnFailure(t *testing.T) {
+	chdir(t, stageTestProject(t, `{}`))
+
+	results, err := runStages([]Stage{{Name: "vet"}, {Name: "test"}})
+	if err == nil {
+		t.Fatal("runStages should fail when go vet reports problems")
+	}
+	if len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("runStages should stop after the failed vet stage, got %+v", results)
+	}
+
+	results, err = runStages([]Stage{{Name: "vet", AllowFailure: true}})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("allow_failure stage should not stop the build: %v", err)
+	}
+}
+
+func TestBuildStageFailureSetsExitCode(t *testing.T) {
+	chdir(t, stageTestProject(t, `{"stages": ["vet"]}`))
+	resetExitCode(t)
+	defer func(config *BuildConfig) { buildConfig = config }(buildConfig)
+
+	buildCmd.Run(buildCmd, nil)
+	if exitCode == 0 {
+		t.Fatal("build should exit non-zero when a stage fails")
+	}
+}
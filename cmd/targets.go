@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// getTargetsToBuild 根据 -t 确定要构建的目标平台。
+//
+// -t 是逗号分隔的选择器列表，按顺序处理：
+//   - all：内置的常用目标平台列表 (buildTargets)
+//   - os/arch：单个目标平台，如 linux/amd64
+//   - 通配符：如 linux/*、*/arm64，匹配已安装的工具链支持的所有目标平台
+//   - !选择器：从已选择的目标中排除，如 all,!windows/386；只有排除项时从 all 中排除
+//
+// 目标平台是否受支持以 go tool dist list 的结果为准。
+func getTargetsToBuild() ([]BuildTarget, error) {
+	if targetFlag == "" {
+		// 默认构建当前平台
+		return []BuildTarget{{runtime.GOOS, runtime.GOARCH}}, nil
+	}
+
+	supported, err := goDistList()
+	if err != nil {
+		logWarn(T("build.target_list_unavailable", err))
+		supported = buildTargets
+	}
+
+	var includes, excludes []string
+	for _, selector := range strings.Split(targetFlag, ",") {
+		selector = strings.TrimSpace(selector)
+		if selector == "" {
+			continue
+		}
+		if pattern, ok := strings.CutPrefix(selector, "!"); ok {
+			excludes = append(excludes, pattern)
+		} else {
+			includes = append(includes, selector)
+		}
+	}
+	if len(includes) == 0 {
+		includes = []string{"all"}
+	}
+
+	var targets []BuildTarget
+	for _, selector := range includes {
+		matched, err := matchTargets(selector, supported)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range matched {
+			if !containsTarget(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	for _, pattern := range excludes {
+		if _, err := matchTargets(pattern, supported); err != nil {
+			return nil, err
+		}
+		var kept []BuildTarget
+		for _, target := range targets {
+			if !targetMatches(pattern, target) {
+				kept = append(kept, target)
+			}
+		}
+		targets = kept
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf(T("build.no_targets_selected"), targetFlag)
+	}
+	return targets, nil
+}
+
+// matchTargets 返回选择器匹配的目标平台，选择器无效或没有匹配时返回带有建议的错误
+func matchTargets(selector string, supported []BuildTarget) ([]BuildTarget, error) {
+	if selector == "all" {
+		var targets []BuildTarget
+		for _, target := range buildTargets {
+			if containsTarget(supported, target) {
+				targets = append(targets, target)
+			} else {
+				logWarn(T("build.all_target_unsupported", target.OS+"/"+target.Arch))
+			}
+		}
+		return targets, nil
+	}
+
+	goos, goarch, ok := strings.Cut(selector, "/")
+	if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+		return nil, fmt.Errorf(T("build.invalid_target"), selector)
+	}
+	if _, err := path.Match(selector, ""); err != nil {
+		return nil, fmt.Errorf(T("build.invalid_target"), selector)
+	}
+
+	var targets []BuildTarget
+	for _, target := range supported {
+		if targetMatches(selector, target) {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) > 0 {
+		return targets, nil
+	}
+
+	if suggestion := suggestTarget(goos, goarch, supported); suggestion != "" {
+		return nil, fmt.Errorf(T("build.unsupported_target_suggest"), selector, suggestion)
+	}
+	return nil, fmt.Errorf(T("build.unsupported_target"), selector)
+}
+
+// targetMatches 判断目标平台是否匹配选择器，* 匹配 os 或 arch 中的任意字符
+func targetMatches(pattern string, target BuildTarget) bool {
+	matched, _ := path.Match(pattern, target.OS+"/"+target.Arch)
+	return matched
+}
+
+// suggestTarget 返回与选择器最接近的有效写法。
+// 不含通配符的部分分别与受支持的 os 和 arch 比较，替换为编辑距离最小的值。
+func suggestTarget(goos, goarch string, supported []BuildTarget) string {
+	var oses, arches []string
+	for _, target := range supported {
+		oses = append(oses, target.OS)
+		arches = append(arches, target.Arch)
+	}
+
+	if !strings.Contains(goos, "*") {
+		goos = closestString(goos, oses)
+	}
+	if !strings.Contains(goarch, "*") {
+		goarch = closestString(goarch, arches)
+	}
+
+	suggestion := goos + "/" + goarch
+	for _, target := range supported {
+		if targetMatches(suggestion, target) {
+			return suggestion
+		}
+	}
+
+	// os 和 arch 分别修正后的组合无效时，在完整的目标列表中查找
+	if strings.Contains(suggestion, "*") {
+		return ""
+	}
+	var names []string
+	for _, target := range supported {
+		names = append(names, target.OS+"/"+target.Arch)
+	}
+	return closestString(suggestion, names)
+}
+
+// closestString 返回候选列表中与 s 编辑距离最小的字符串
+func closestString(s string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		if d := editDistance(s, candidate); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance 计算两个字符串的 Levenshtein 编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
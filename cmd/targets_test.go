@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubDistList 用固定的目标平台列表代替 go tool dist list 的结果
+func stubDistList(t *testing.T, targets []BuildTarget) {
+	t.Helper()
+	saved, savedFlag := distListCache, targetFlag
+	t.Cleanup(func() { distListCache, targetFlag = saved, savedFlag })
+	distListCache = targets
+}
+
+// testDistList 测试使用的受支持目标平台，android/arm64 和 freebsd/arm64 不在 buildTargets 中
+var testDistList = []BuildTarget{
+	{"android", "arm64"},
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"freebsd", "arm64"},
+	{"linux", "386"},
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"windows", "386"},
+	{"windows", "amd64"},
+	{"windows", "arm64"},
+}
+
+// formatTargets 把目标平台列表格式化为 os/arch 列表，便于比较
+func formatTargets(targets []BuildTarget) []string {
+	names := []string{}
+	for _, target := range targets {
+		names = append(names, target.OS+"/"+target.Arch)
+	}
+	return names
+}
+
+func TestGetTargetsToBuild(t *testing.T) {
+	stubDistList(t, testDistList)
+
+	tests := []struct {
+		flag string
+		want []string
+	}{
+		{"linux/amd64", []string{"linux/amd64"}},
+		{"*/arm64", []string{"android/arm64", "darwin/arm64", "freebsd/arm64", "linux/arm64", "windows/arm64"}},
+		{"linux/*,!linux/386", []string{"linux/amd64", "linux/arm64"}},
+		{"linux/amd64,linux/*", []string{"linux/amd64", "linux/386", "linux/arm64"}},
+		{"!windows/*,!darwin/*", []string{"linux/386", "linux/amd64", "linux/arm64"}},
+		{"all,!*/386", []string{"windows/amd64", "windows/arm64", "darwin/amd64", "darwin/arm64", "linux/amd64", "linux/arm64"}},
+	}
+	for _, tt := range tests {
+		targetFlag = tt.flag
+		targets, err := getTargetsToBuild()
+		if err != nil {
+			t.Errorf("-t %s: %v", tt.flag, err)
+			continue
+		}
+		if got := formatTargets(targets); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("-t %s = %v, want %v", tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestGetTargetsToBuildErrors(t *testing.T) {
+	stubDistList(t, testDistList)
+
+	tests := []struct {
+		flag string
+		want string
+	}{
+		{"linux/*,!linux/*", fmt.Sprintf(T("build.no_targets_selected"), "linux/*,!linux/*")},
+		{"linx/amd64", fmt.Sprintf(T("build.unsupported_target_suggest"), "linx/amd64", "linux/amd64")},
+		{"linux/amd64,!linx/386", fmt.Sprintf(T("build.unsupported_target_suggest"), "linx/386", "linux/386")},
+		{"plan9/*", fmt.Sprintf(T("build.unsupported_target_suggest"), "plan9/*", "linux/*")},
+		{"linux", fmt.Sprintf(T("build.invalid_target"), "linux")},
+		{"linux/[", fmt.Sprintf(T("build.invalid_target"), "linux/[")},
+	}
+	for _, tt := range tests {
+		targetFlag = tt.flag
+		_, err := getTargetsToBuild()
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("-t %s: error = %v, want %q", tt.flag, err, tt.want)
+		}
+	}
+}
+
+func TestMatchTargets(t *testing.T) {
+	supported := []BuildTarget{{"linux", "amd64"}, {"linux", "arm64"}, {"darwin", "arm64"}}
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"all", []string{"darwin/arm64", "linux/amd64", "linux/arm64"}},
+		{"*/arm64", []string{"linux/arm64", "darwin/arm64"}},
+		{"linux/*", []string{"linux/amd64", "linux/arm64"}},
+		{"darwin/arm64", []string{"darwin/arm64"}},
+	}
+	for _, tt := range tests {
+		targets, err := matchTargets(tt.selector, supported)
+		if err != nil {
+			t.Errorf("matchTargets(%q): %v", tt.selector, err)
+			continue
+		}
+		if got := formatTargets(targets); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchTargets(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+
+	// 所有受支持的平台都不匹配时返回错误而不是空列表
+	if _, err := matchTargets("windows/amd64", supported); err == nil {
+		t.Error("matchTargets for an unsupported target should be an error")
+	}
+}
+
+func TestGetTargetsToBuildFallback(t *testing.T) {
+	stubDistList(t, nil)
+	t.Setenv("PATH", "")
+
+	targetFlag = "*/arm64"
+	targets, err := getTargetsToBuild()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(formatTargets(targets), ","), "windows/arm64,darwin/arm64,linux/arm64"; got != want {
+		t.Errorf("without go tool dist list, -t */arm64 = %s, want %s (from buildTargets)", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// distListCache go tool dist list 的结果，同一进程内只执行一次
+var distListCache []BuildTarget
+
+// goDistList 返回已安装的 Go 工具链支持的所有目标平台 (go tool dist list)
+func goDistList() ([]BuildTarget, error) {
+	if distListCache != nil {
+		return distListCache, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "tool", "dist", "list")
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf(T("toolchain.dist_list_failed"), fmt.Errorf("%s", msg))
+		}
+		return nil, fmt.Errorf(T("toolchain.dist_list_failed"), err)
+	}
+
+	var targets []BuildTarget
+	for _, line := range strings.Fields(string(output)) {
+		if goos, goarch, ok := strings.Cut(line, "/"); ok {
+			targets = append(targets, BuildTarget{goos, goarch})
+		}
+	}
+	distListCache = targets
+	return targets, nil
+}
+
+// goEnv 返回 go env 中变量的值
+func goEnv(name string) (string, error) {
+	output, err := exec.Command("go", "env", name).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// goModDirectives 读取 go.mod 中的 go 和 toolchain 指令，没有对应指令时返回空字符串
+func goModDirectives(path string) (goVersion, toolchain string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			goVersion = fields[1]
+		case "toolchain":
+			toolchain = strings.TrimPrefix(fields[1], "go")
+		}
+	}
+	return goVersion, toolchain, scanner.Err()
+}
+
+// compareGoVersions 比较 1.22、1.22.3、1.23rc1 形式的 Go 版本号，a 较低时返回负数。
+// 预发布版本低于同一版本号的正式版本。
+func compareGoVersions(a, b string) int {
+	pa, preA := splitGoVersion(a)
+	pb, preB := splitGoVersion(b)
+	for i := 0; i < 3; i++ {
+		if pa[i] != pb[i] {
+			return pa[i] - pb[i]
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return strings.Compare(preA, preB)
+}
+
+// splitGoVersion 将 Go 版本号拆分为主、次、补丁版本号和预发布后缀
+func splitGoVersion(version string) ([3]int, string) {
+	var parts [3]int
+	version = strings.TrimPrefix(version, "go")
+	pre := ""
+	if i := strings.IndexAny(version, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		version, pre = version[:i], version[i:]
+	}
+	for i, field := range strings.SplitN(version, ".", 3) {
+		parts[i], _ = strconv.Atoi(field)
+	}
+	return parts, pre
+}